@@ -27,6 +27,8 @@ type AccountStatus int32
 const (
 	AccountStatus_ACTIVE AccountStatus = 0
 	AccountStatus_CLOSED AccountStatus = 1
+	// FROZEN accounts accept no new transactions until set back to ACTIVE.
+	AccountStatus_FROZEN AccountStatus = 2
 )
 
 // Enum value maps for AccountStatus.
@@ -34,10 +36,12 @@ var (
 	AccountStatus_name = map[int32]string{
 		0: "ACTIVE",
 		1: "CLOSED",
+		2: "FROZEN",
 	}
 	AccountStatus_value = map[string]int32{
 		"ACTIVE": 0,
 		"CLOSED": 1,
+		"FROZEN": 2,
 	}
 )
 
@@ -382,16 +386,123 @@ func (x *AccountResponse) GetUpdatedAt() string {
 	return ""
 }
 
+type ListAccountsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Capped server-side; defaults to 20.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque cursor returned as next_page_token by the previous call.
+	PageToken  string          `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Statuses   []AccountStatus `protobuf:"varint,3,rep,packed,name=statuses,proto3,enum=bankLedger.v1.AccountStatus" json:"statuses,omitempty"`
+	Currency   Currency        `protobuf:"varint,4,opt,name=currency,proto3,enum=bankLedger.v1.Currency" json:"currency,omitempty"`
+	NamePrefix string          `protobuf:"bytes,5,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	// RFC3339 timestamp or YYYY-MM-DD date, inclusive.
+	CreatedFrom string `protobuf:"bytes,6,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	// RFC3339 timestamp or YYYY-MM-DD date, exclusive for timestamps and inclusive for dates.
+	CreatedTo string `protobuf:"bytes,7,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	// One of created_at, name or balance, optionally followed by asc or desc. Defaults to "created_at desc".
+	OrderBy       string `protobuf:"bytes,8,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsRequest) Reset() {
+	*x = ListAccountsRequest{}
+	mi := &file_bankLedger_v1_account_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsRequest) ProtoMessage() {}
+
+func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bankLedger_v1_account_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_bankLedger_v1_account_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAccountsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAccountsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListAccountsRequest) GetStatuses() []AccountStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListAccountsRequest) GetCurrency() Currency {
+	if x != nil {
+		return x.Currency
+	}
+	return Currency_CURRENCY_UNSPECIFIED
+}
+
+func (x *ListAccountsRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ListAccountsRequest) GetCreatedFrom() string {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return ""
+}
+
+func (x *ListAccountsRequest) GetCreatedTo() string {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return ""
+}
+
+func (x *ListAccountsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
 type GetAllAccountsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Accounts      []*AccountResponse     `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Accounts []*AccountResponse     `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	// Empty when there are no more results.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetAllAccountsResponse) Reset() {
 	*x = GetAllAccountsResponse{}
-	mi := &file_bankLedger_v1_account_proto_msgTypes[5]
+	mi := &file_bankLedger_v1_account_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -403,7 +514,7 @@ func (x *GetAllAccountsResponse) String() string {
 func (*GetAllAccountsResponse) ProtoMessage() {}
 
 func (x *GetAllAccountsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bankLedger_v1_account_proto_msgTypes[5]
+	mi := &file_bankLedger_v1_account_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -416,7 +527,7 @@ func (x *GetAllAccountsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAllAccountsResponse.ProtoReflect.Descriptor instead.
 func (*GetAllAccountsResponse) Descriptor() ([]byte, []int) {
-	return file_bankLedger_v1_account_proto_rawDescGZIP(), []int{5}
+	return file_bankLedger_v1_account_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetAllAccountsResponse) GetAccounts() []*AccountResponse {
@@ -426,6 +537,13 @@ func (x *GetAllAccountsResponse) GetAccounts() []*AccountResponse {
 	return nil
 }
 
+func (x *GetAllAccountsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type UpdateAccountRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -437,7 +555,7 @@ type UpdateAccountRequest struct {
 
 func (x *UpdateAccountRequest) Reset() {
 	*x = UpdateAccountRequest{}
-	mi := &file_bankLedger_v1_account_proto_msgTypes[6]
+	mi := &file_bankLedger_v1_account_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -449,7 +567,7 @@ func (x *UpdateAccountRequest) String() string {
 func (*UpdateAccountRequest) ProtoMessage() {}
 
 func (x *UpdateAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bankLedger_v1_account_proto_msgTypes[6]
+	mi := &file_bankLedger_v1_account_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -462,7 +580,7 @@ func (x *UpdateAccountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateAccountRequest.ProtoReflect.Descriptor instead.
 func (*UpdateAccountRequest) Descriptor() ([]byte, []int) {
-	return file_bankLedger_v1_account_proto_rawDescGZIP(), []int{6}
+	return file_bankLedger_v1_account_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateAccountRequest) GetId() string {
@@ -495,7 +613,7 @@ type DeleteAccountResponse struct {
 
 func (x *DeleteAccountResponse) Reset() {
 	*x = DeleteAccountResponse{}
-	mi := &file_bankLedger_v1_account_proto_msgTypes[7]
+	mi := &file_bankLedger_v1_account_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -507,7 +625,7 @@ func (x *DeleteAccountResponse) String() string {
 func (*DeleteAccountResponse) ProtoMessage() {}
 
 func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bankLedger_v1_account_proto_msgTypes[7]
+	mi := &file_bankLedger_v1_account_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,7 +638,7 @@ func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteAccountResponse.ProtoReflect.Descriptor instead.
 func (*DeleteAccountResponse) Descriptor() ([]byte, []int) {
-	return file_bankLedger_v1_account_proto_rawDescGZIP(), []int{7}
+	return file_bankLedger_v1_account_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DeleteAccountResponse) GetSuccess() bool {
@@ -530,6 +648,289 @@ func (x *DeleteAccountResponse) GetSuccess() bool {
 	return false
 }
 
+type GetBalanceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// RFC3339 timestamp or YYYY-MM-DD date, which means the end of that day.
+	// Defaults to now.
+	AsOf          string `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceRequest) Reset() {
+	*x = GetBalanceRequest{}
+	mi := &file_bankLedger_v1_account_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceRequest) ProtoMessage() {}
+
+func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bankLedger_v1_account_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_bankLedger_v1_account_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetBalanceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetBalanceRequest) GetAsOf() string {
+	if x != nil {
+		return x.AsOf
+	}
+	return ""
+}
+
+type BalanceResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Balance   string                 `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Currency  Currency               `protobuf:"varint,3,opt,name=currency,proto3,enum=bankLedger.v1.Currency" json:"currency,omitempty"`
+	AsOf      string                 `protobuf:"bytes,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	// Day of the end-of-day snapshot the balance was computed from; empty when
+	// none applied.
+	SnapshotDate  string `protobuf:"bytes,5,opt,name=snapshot_date,json=snapshotDate,proto3" json:"snapshot_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceResponse) Reset() {
+	*x = BalanceResponse{}
+	mi := &file_bankLedger_v1_account_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceResponse) ProtoMessage() {}
+
+func (x *BalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bankLedger_v1_account_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceResponse.ProtoReflect.Descriptor instead.
+func (*BalanceResponse) Descriptor() ([]byte, []int) {
+	return file_bankLedger_v1_account_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BalanceResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *BalanceResponse) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *BalanceResponse) GetCurrency() Currency {
+	if x != nil {
+		return x.Currency
+	}
+	return Currency_CURRENCY_UNSPECIFIED
+}
+
+func (x *BalanceResponse) GetAsOf() string {
+	if x != nil {
+		return x.AsOf
+	}
+	return ""
+}
+
+func (x *BalanceResponse) GetSnapshotDate() string {
+	if x != nil {
+		return x.SnapshotDate
+	}
+	return ""
+}
+
+// BalanceDiscrepancy is an account whose stored balance differs from the sum
+// of its SUCCESS transactions. It is the payload of account.balance_mismatch
+// webhooks.
+type BalanceDiscrepancy struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Account *AccountResponse       `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Balance recomputed from SUCCESS transactions.
+	ExpectedBalance string `protobuf:"bytes,2,opt,name=expected_balance,json=expectedBalance,proto3" json:"expected_balance,omitempty"`
+	// recorded balance minus expected balance.
+	Difference string `protobuf:"bytes,3,opt,name=difference,proto3" json:"difference,omitempty"`
+	// Whether reconciliation froze the account.
+	Frozen        bool   `protobuf:"varint,4,opt,name=frozen,proto3" json:"frozen,omitempty"`
+	CheckedAt     string `protobuf:"bytes,5,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceDiscrepancy) Reset() {
+	*x = BalanceDiscrepancy{}
+	mi := &file_bankLedger_v1_account_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceDiscrepancy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceDiscrepancy) ProtoMessage() {}
+
+func (x *BalanceDiscrepancy) ProtoReflect() protoreflect.Message {
+	mi := &file_bankLedger_v1_account_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceDiscrepancy.ProtoReflect.Descriptor instead.
+func (*BalanceDiscrepancy) Descriptor() ([]byte, []int) {
+	return file_bankLedger_v1_account_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BalanceDiscrepancy) GetAccount() *AccountResponse {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *BalanceDiscrepancy) GetExpectedBalance() string {
+	if x != nil {
+		return x.ExpectedBalance
+	}
+	return ""
+}
+
+func (x *BalanceDiscrepancy) GetDifference() string {
+	if x != nil {
+		return x.Difference
+	}
+	return ""
+}
+
+func (x *BalanceDiscrepancy) GetFrozen() bool {
+	if x != nil {
+		return x.Frozen
+	}
+	return false
+}
+
+func (x *BalanceDiscrepancy) GetCheckedAt() string {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return ""
+}
+
+// ReconciliationReport is written by the reconciliation command.
+type ReconciliationReport struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	StartedAt       string                 `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt      string                 `protobuf:"bytes,2,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	AccountsChecked int32                  `protobuf:"varint,3,opt,name=accounts_checked,json=accountsChecked,proto3" json:"accounts_checked,omitempty"`
+	Discrepancies   []*BalanceDiscrepancy  `protobuf:"bytes,4,rep,name=discrepancies,proto3" json:"discrepancies,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReconciliationReport) Reset() {
+	*x = ReconciliationReport{}
+	mi := &file_bankLedger_v1_account_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconciliationReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconciliationReport) ProtoMessage() {}
+
+func (x *ReconciliationReport) ProtoReflect() protoreflect.Message {
+	mi := &file_bankLedger_v1_account_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconciliationReport.ProtoReflect.Descriptor instead.
+func (*ReconciliationReport) Descriptor() ([]byte, []int) {
+	return file_bankLedger_v1_account_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReconciliationReport) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *ReconciliationReport) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+func (x *ReconciliationReport) GetAccountsChecked() int32 {
+	if x != nil {
+		return x.AccountsChecked
+	}
+	return 0
+}
+
+func (x *ReconciliationReport) GetDiscrepancies() []*BalanceDiscrepancy {
+	if x != nil {
+		return x.Discrepancies
+	}
+	return nil
+}
+
 var File_bankLedger_v1_account_proto protoreflect.FileDescriptor
 
 const file_bankLedger_v1_account_proto_rawDesc = "" +
@@ -550,30 +951,73 @@ const file_bankLedger_v1_account_proto_rawDesc = "" +
 	"\bcurrency\x18\x05 \x01(\x0e2\x17.bankLedger.v1.CurrencyR\bcurrency\x124\n" +
 	"\x06status\x18\x06 \x01(\x0e2\x1c.bankLedger.v1.AccountStatusR\x06status\x12\x1c\n" +
 	"\tcreatedAt\x18\a \x01(\tR\tcreatedAt\x12\x1c\n" +
-	"\tupdatedAt\x18\b \x01(\tR\tupdatedAt\"T\n" +
+	"\tupdatedAt\x18\b \x01(\tR\tupdatedAt\"\xbe\x02\n" +
+	"\x13ListAccountsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x128\n" +
+	"\bstatuses\x18\x03 \x03(\x0e2\x1c.bankLedger.v1.AccountStatusR\bstatuses\x123\n" +
+	"\bcurrency\x18\x04 \x01(\x0e2\x17.bankLedger.v1.CurrencyR\bcurrency\x12\x1f\n" +
+	"\vname_prefix\x18\x05 \x01(\tR\n" +
+	"namePrefix\x12!\n" +
+	"\fcreated_from\x18\x06 \x01(\tR\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\a \x01(\tR\tcreatedTo\x12\x19\n" +
+	"\border_by\x18\b \x01(\tR\aorderBy\"|\n" +
 	"\x16GetAllAccountsResponse\x12:\n" +
-	"\baccounts\x18\x01 \x03(\v2\x1e.bankLedger.v1.AccountResponseR\baccounts\"p\n" +
+	"\baccounts\x18\x01 \x03(\v2\x1e.bankLedger.v1.AccountResponseR\baccounts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"p\n" +
 	"\x14UpdateAccountRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x124\n" +
 	"\x06status\x18\x03 \x01(\x0e2\x1c.bankLedger.v1.AccountStatusR\x06status\"1\n" +
 	"\x15DeleteAccountResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess*'\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"8\n" +
+	"\x11GetBalanceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x13\n" +
+	"\x05as_of\x18\x02 \x01(\tR\x04asOf\"\xb9\x01\n" +
+	"\x0fBalanceResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\tR\abalance\x123\n" +
+	"\bcurrency\x18\x03 \x01(\x0e2\x17.bankLedger.v1.CurrencyR\bcurrency\x12\x13\n" +
+	"\x05as_of\x18\x04 \x01(\tR\x04asOf\x12#\n" +
+	"\rsnapshot_date\x18\x05 \x01(\tR\fsnapshotDate\"\xd0\x01\n" +
+	"\x12BalanceDiscrepancy\x128\n" +
+	"\aaccount\x18\x01 \x01(\v2\x1e.bankLedger.v1.AccountResponseR\aaccount\x12)\n" +
+	"\x10expected_balance\x18\x02 \x01(\tR\x0fexpectedBalance\x12\x1e\n" +
+	"\n" +
+	"difference\x18\x03 \x01(\tR\n" +
+	"difference\x12\x16\n" +
+	"\x06frozen\x18\x04 \x01(\bR\x06frozen\x12\x1d\n" +
+	"\n" +
+	"checked_at\x18\x05 \x01(\tR\tcheckedAt\"\xca\x01\n" +
+	"\x14ReconciliationReport\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x01 \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x02 \x01(\tR\n" +
+	"finishedAt\x12)\n" +
+	"\x10accounts_checked\x18\x03 \x01(\x05R\x0faccountsChecked\x12G\n" +
+	"\rdiscrepancies\x18\x04 \x03(\v2!.bankLedger.v1.BalanceDiscrepancyR\rdiscrepancies*3\n" +
 	"\rAccountStatus\x12\n" +
 	"\n" +
 	"\x06ACTIVE\x10\x00\x12\n" +
 	"\n" +
-	"\x06CLOSED\x10\x01*-\n" +
+	"\x06CLOSED\x10\x01\x12\n" +
+	"\n" +
+	"\x06FROZEN\x10\x02*-\n" +
 	"\bCurrency\x12\x18\n" +
 	"\x14CURRENCY_UNSPECIFIED\x10\x00\x12\a\n" +
-	"\x03INR\x10\x012\xa6\x04\n" +
+	"\x03INR\x10\x012\x9f\x05\n" +
 	"\aAccount\x12l\n" +
 	"\rCreateAccount\x12#.bankLedger.v1.CreateAccountRequest\x1a\x1e.bankLedger.v1.AccountResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/account\x12b\n" +
 	"\n" +
-	"GetAccount\x12\x1a.bankLedger.v1.BaseRequest\x1a\x1e.bankLedger.v1.AccountResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/account/{id}\x12i\n" +
-	"\x0eGetAllAccounts\x12\x1b.bankLedger.v1.EmptyRequest\x1a%.bankLedger.v1.GetAllAccountsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/account\x12q\n" +
+	"GetAccount\x12\x1a.bankLedger.v1.BaseRequest\x1a\x1e.bankLedger.v1.AccountResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/account/{id}\x12p\n" +
+	"\x0eGetAllAccounts\x12\".bankLedger.v1.ListAccountsRequest\x1a%.bankLedger.v1.GetAllAccountsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/account\x12q\n" +
 	"\rUpdateAccount\x12#.bankLedger.v1.UpdateAccountRequest\x1a\x1e.bankLedger.v1.AccountResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\x1a\x10/v1/account/{id}\x12k\n" +
-	"\rDeleteAccount\x12\x1a.bankLedger.v1.BaseRequest\x1a$.bankLedger.v1.DeleteAccountResponse\"\x18\x82\xd3\xe4\x93\x02\x12*\x10/v1/account/{id}B]\n" +
+	"\rDeleteAccount\x12\x1a.bankLedger.v1.BaseRequest\x1a$.bankLedger.v1.DeleteAccountResponse\"\x18\x82\xd3\xe4\x93\x02\x12*\x10/v1/account/{id}\x12p\n" +
+	"\n" +
+	"GetBalance\x12 .bankLedger.v1.GetBalanceRequest\x1a\x1e.bankLedger.v1.BalanceResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/account/{id}/balanceB]\n" +
 	"\x1cdev.kratos.api.bankLedger.v1B\x11BankLedgerProtoV1P\x01Z(bank-ledger-service/api/bankLedger/v1;v1b\x06proto3"
 
 var (
@@ -589,7 +1033,7 @@ func file_bankLedger_v1_account_proto_rawDescGZIP() []byte {
 }
 
 var file_bankLedger_v1_account_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_bankLedger_v1_account_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_bankLedger_v1_account_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_bankLedger_v1_account_proto_goTypes = []any{
 	(AccountStatus)(0),             // 0: bankLedger.v1.AccountStatus
 	(Currency)(0),                  // 1: bankLedger.v1.Currency
@@ -598,31 +1042,43 @@ var file_bankLedger_v1_account_proto_goTypes = []any{
 	(*BaseResponse)(nil),           // 4: bankLedger.v1.BaseResponse
 	(*CreateAccountRequest)(nil),   // 5: bankLedger.v1.CreateAccountRequest
 	(*AccountResponse)(nil),        // 6: bankLedger.v1.AccountResponse
-	(*GetAllAccountsResponse)(nil), // 7: bankLedger.v1.GetAllAccountsResponse
-	(*UpdateAccountRequest)(nil),   // 8: bankLedger.v1.UpdateAccountRequest
-	(*DeleteAccountResponse)(nil),  // 9: bankLedger.v1.DeleteAccountResponse
+	(*ListAccountsRequest)(nil),    // 7: bankLedger.v1.ListAccountsRequest
+	(*GetAllAccountsResponse)(nil), // 8: bankLedger.v1.GetAllAccountsResponse
+	(*UpdateAccountRequest)(nil),   // 9: bankLedger.v1.UpdateAccountRequest
+	(*DeleteAccountResponse)(nil),  // 10: bankLedger.v1.DeleteAccountResponse
+	(*GetBalanceRequest)(nil),      // 11: bankLedger.v1.GetBalanceRequest
+	(*BalanceResponse)(nil),        // 12: bankLedger.v1.BalanceResponse
+	(*BalanceDiscrepancy)(nil),     // 13: bankLedger.v1.BalanceDiscrepancy
+	(*ReconciliationReport)(nil),   // 14: bankLedger.v1.ReconciliationReport
 }
 var file_bankLedger_v1_account_proto_depIdxs = []int32{
 	1,  // 0: bankLedger.v1.CreateAccountRequest.currency:type_name -> bankLedger.v1.Currency
 	1,  // 1: bankLedger.v1.AccountResponse.currency:type_name -> bankLedger.v1.Currency
 	0,  // 2: bankLedger.v1.AccountResponse.status:type_name -> bankLedger.v1.AccountStatus
-	6,  // 3: bankLedger.v1.GetAllAccountsResponse.accounts:type_name -> bankLedger.v1.AccountResponse
-	0,  // 4: bankLedger.v1.UpdateAccountRequest.status:type_name -> bankLedger.v1.AccountStatus
-	5,  // 5: bankLedger.v1.Account.CreateAccount:input_type -> bankLedger.v1.CreateAccountRequest
-	3,  // 6: bankLedger.v1.Account.GetAccount:input_type -> bankLedger.v1.BaseRequest
-	2,  // 7: bankLedger.v1.Account.GetAllAccounts:input_type -> bankLedger.v1.EmptyRequest
-	8,  // 8: bankLedger.v1.Account.UpdateAccount:input_type -> bankLedger.v1.UpdateAccountRequest
-	3,  // 9: bankLedger.v1.Account.DeleteAccount:input_type -> bankLedger.v1.BaseRequest
-	6,  // 10: bankLedger.v1.Account.CreateAccount:output_type -> bankLedger.v1.AccountResponse
-	6,  // 11: bankLedger.v1.Account.GetAccount:output_type -> bankLedger.v1.AccountResponse
-	7,  // 12: bankLedger.v1.Account.GetAllAccounts:output_type -> bankLedger.v1.GetAllAccountsResponse
-	6,  // 13: bankLedger.v1.Account.UpdateAccount:output_type -> bankLedger.v1.AccountResponse
-	9,  // 14: bankLedger.v1.Account.DeleteAccount:output_type -> bankLedger.v1.DeleteAccountResponse
-	10, // [10:15] is the sub-list for method output_type
-	5,  // [5:10] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	0,  // 3: bankLedger.v1.ListAccountsRequest.statuses:type_name -> bankLedger.v1.AccountStatus
+	1,  // 4: bankLedger.v1.ListAccountsRequest.currency:type_name -> bankLedger.v1.Currency
+	6,  // 5: bankLedger.v1.GetAllAccountsResponse.accounts:type_name -> bankLedger.v1.AccountResponse
+	0,  // 6: bankLedger.v1.UpdateAccountRequest.status:type_name -> bankLedger.v1.AccountStatus
+	1,  // 7: bankLedger.v1.BalanceResponse.currency:type_name -> bankLedger.v1.Currency
+	6,  // 8: bankLedger.v1.BalanceDiscrepancy.account:type_name -> bankLedger.v1.AccountResponse
+	13, // 9: bankLedger.v1.ReconciliationReport.discrepancies:type_name -> bankLedger.v1.BalanceDiscrepancy
+	5,  // 10: bankLedger.v1.Account.CreateAccount:input_type -> bankLedger.v1.CreateAccountRequest
+	3,  // 11: bankLedger.v1.Account.GetAccount:input_type -> bankLedger.v1.BaseRequest
+	7,  // 12: bankLedger.v1.Account.GetAllAccounts:input_type -> bankLedger.v1.ListAccountsRequest
+	9,  // 13: bankLedger.v1.Account.UpdateAccount:input_type -> bankLedger.v1.UpdateAccountRequest
+	3,  // 14: bankLedger.v1.Account.DeleteAccount:input_type -> bankLedger.v1.BaseRequest
+	11, // 15: bankLedger.v1.Account.GetBalance:input_type -> bankLedger.v1.GetBalanceRequest
+	6,  // 16: bankLedger.v1.Account.CreateAccount:output_type -> bankLedger.v1.AccountResponse
+	6,  // 17: bankLedger.v1.Account.GetAccount:output_type -> bankLedger.v1.AccountResponse
+	8,  // 18: bankLedger.v1.Account.GetAllAccounts:output_type -> bankLedger.v1.GetAllAccountsResponse
+	6,  // 19: bankLedger.v1.Account.UpdateAccount:output_type -> bankLedger.v1.AccountResponse
+	10, // 20: bankLedger.v1.Account.DeleteAccount:output_type -> bankLedger.v1.DeleteAccountResponse
+	12, // 21: bankLedger.v1.Account.GetBalance:output_type -> bankLedger.v1.BalanceResponse
+	16, // [16:22] is the sub-list for method output_type
+	10, // [10:16] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_bankLedger_v1_account_proto_init() }
@@ -636,7 +1092,7 @@ func file_bankLedger_v1_account_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bankLedger_v1_account_proto_rawDesc), len(file_bankLedger_v1_account_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   8,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
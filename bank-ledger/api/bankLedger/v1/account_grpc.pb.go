@@ -24,6 +24,7 @@ const (
 	Account_GetAllAccounts_FullMethodName = "/bankLedger.v1.Account/GetAllAccounts"
 	Account_UpdateAccount_FullMethodName  = "/bankLedger.v1.Account/UpdateAccount"
 	Account_DeleteAccount_FullMethodName  = "/bankLedger.v1.Account/DeleteAccount"
+	Account_GetBalance_FullMethodName     = "/bankLedger.v1.Account/GetBalance"
 )
 
 // AccountClient is the client API for Account service.
@@ -32,9 +33,10 @@ const (
 type AccountClient interface {
 	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error)
 	GetAccount(ctx context.Context, in *BaseRequest, opts ...grpc.CallOption) (*AccountResponse, error)
-	GetAllAccounts(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetAllAccountsResponse, error)
+	GetAllAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*GetAllAccountsResponse, error)
 	UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error)
 	DeleteAccount(ctx context.Context, in *BaseRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error)
+	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
 }
 
 type accountClient struct {
@@ -65,7 +67,7 @@ func (c *accountClient) GetAccount(ctx context.Context, in *BaseRequest, opts ..
 	return out, nil
 }
 
-func (c *accountClient) GetAllAccounts(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GetAllAccountsResponse, error) {
+func (c *accountClient) GetAllAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*GetAllAccountsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetAllAccountsResponse)
 	err := c.cc.Invoke(ctx, Account_GetAllAccounts_FullMethodName, in, out, cOpts...)
@@ -95,15 +97,26 @@ func (c *accountClient) DeleteAccount(ctx context.Context, in *BaseRequest, opts
 	return out, nil
 }
 
+func (c *accountClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BalanceResponse)
+	err := c.cc.Invoke(ctx, Account_GetBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AccountServer is the server API for Account service.
 // All implementations must embed UnimplementedAccountServer
 // for forward compatibility.
 type AccountServer interface {
 	CreateAccount(context.Context, *CreateAccountRequest) (*AccountResponse, error)
 	GetAccount(context.Context, *BaseRequest) (*AccountResponse, error)
-	GetAllAccounts(context.Context, *EmptyRequest) (*GetAllAccountsResponse, error)
+	GetAllAccounts(context.Context, *ListAccountsRequest) (*GetAllAccountsResponse, error)
 	UpdateAccount(context.Context, *UpdateAccountRequest) (*AccountResponse, error)
 	DeleteAccount(context.Context, *BaseRequest) (*DeleteAccountResponse, error)
+	GetBalance(context.Context, *GetBalanceRequest) (*BalanceResponse, error)
 	mustEmbedUnimplementedAccountServer()
 }
 
@@ -120,7 +133,7 @@ func (UnimplementedAccountServer) CreateAccount(context.Context, *CreateAccountR
 func (UnimplementedAccountServer) GetAccount(context.Context, *BaseRequest) (*AccountResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAccount not implemented")
 }
-func (UnimplementedAccountServer) GetAllAccounts(context.Context, *EmptyRequest) (*GetAllAccountsResponse, error) {
+func (UnimplementedAccountServer) GetAllAccounts(context.Context, *ListAccountsRequest) (*GetAllAccountsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllAccounts not implemented")
 }
 func (UnimplementedAccountServer) UpdateAccount(context.Context, *UpdateAccountRequest) (*AccountResponse, error) {
@@ -129,6 +142,9 @@ func (UnimplementedAccountServer) UpdateAccount(context.Context, *UpdateAccountR
 func (UnimplementedAccountServer) DeleteAccount(context.Context, *BaseRequest) (*DeleteAccountResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
 }
+func (UnimplementedAccountServer) GetBalance(context.Context, *GetBalanceRequest) (*BalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
 func (UnimplementedAccountServer) mustEmbedUnimplementedAccountServer() {}
 func (UnimplementedAccountServer) testEmbeddedByValue()                 {}
 
@@ -187,7 +203,7 @@ func _Account_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(
 }
 
 func _Account_GetAllAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyRequest)
+	in := new(ListAccountsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -199,7 +215,7 @@ func _Account_GetAllAccounts_Handler(srv interface{}, ctx context.Context, dec f
 		FullMethod: Account_GetAllAccounts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AccountServer).GetAllAccounts(ctx, req.(*EmptyRequest))
+		return srv.(AccountServer).GetAllAccounts(ctx, req.(*ListAccountsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -240,6 +256,24 @@ func _Account_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Account_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Account_GetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Account_ServiceDesc is the grpc.ServiceDesc for Account service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +301,10 @@ var Account_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAccount",
 			Handler:    _Account_DeleteAccount_Handler,
 		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _Account_GetBalance_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "bankLedger/v1/account.proto",
@@ -23,13 +23,15 @@ const OperationAccountCreateAccount = "/bankLedger.v1.Account/CreateAccount"
 const OperationAccountDeleteAccount = "/bankLedger.v1.Account/DeleteAccount"
 const OperationAccountGetAccount = "/bankLedger.v1.Account/GetAccount"
 const OperationAccountGetAllAccounts = "/bankLedger.v1.Account/GetAllAccounts"
+const OperationAccountGetBalance = "/bankLedger.v1.Account/GetBalance"
 const OperationAccountUpdateAccount = "/bankLedger.v1.Account/UpdateAccount"
 
 type AccountHTTPServer interface {
 	CreateAccount(context.Context, *CreateAccountRequest) (*AccountResponse, error)
 	DeleteAccount(context.Context, *BaseRequest) (*DeleteAccountResponse, error)
 	GetAccount(context.Context, *BaseRequest) (*AccountResponse, error)
-	GetAllAccounts(context.Context, *EmptyRequest) (*GetAllAccountsResponse, error)
+	GetAllAccounts(context.Context, *ListAccountsRequest) (*GetAllAccountsResponse, error)
+	GetBalance(context.Context, *GetBalanceRequest) (*BalanceResponse, error)
 	UpdateAccount(context.Context, *UpdateAccountRequest) (*AccountResponse, error)
 }
 
@@ -40,6 +42,7 @@ func RegisterAccountHTTPServer(s *http.Server, srv AccountHTTPServer) {
 	r.GET("/v1/account", _Account_GetAllAccounts0_HTTP_Handler(srv))
 	r.PUT("/v1/account/{id}", _Account_UpdateAccount0_HTTP_Handler(srv))
 	r.DELETE("/v1/account/{id}", _Account_DeleteAccount0_HTTP_Handler(srv))
+	r.GET("/v1/account/{id}/balance", _Account_GetBalance0_HTTP_Handler(srv))
 }
 
 func _Account_CreateAccount0_HTTP_Handler(srv AccountHTTPServer) func(ctx http.Context) error {
@@ -88,13 +91,13 @@ func _Account_GetAccount0_HTTP_Handler(srv AccountHTTPServer) func(ctx http.Cont
 
 func _Account_GetAllAccounts0_HTTP_Handler(srv AccountHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
-		var in EmptyRequest
+		var in ListAccountsRequest
 		if err := ctx.BindQuery(&in); err != nil {
 			return err
 		}
 		http.SetOperation(ctx, OperationAccountGetAllAccounts)
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.GetAllAccounts(ctx, req.(*EmptyRequest))
+			return srv.GetAllAccounts(ctx, req.(*ListAccountsRequest))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
@@ -152,11 +155,34 @@ func _Account_DeleteAccount0_HTTP_Handler(srv AccountHTTPServer) func(ctx http.C
 	}
 }
 
+func _Account_GetBalance0_HTTP_Handler(srv AccountHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetBalanceRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAccountGetBalance)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetBalance(ctx, req.(*GetBalanceRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*BalanceResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 type AccountHTTPClient interface {
 	CreateAccount(ctx context.Context, req *CreateAccountRequest, opts ...http.CallOption) (rsp *AccountResponse, err error)
 	DeleteAccount(ctx context.Context, req *BaseRequest, opts ...http.CallOption) (rsp *DeleteAccountResponse, err error)
 	GetAccount(ctx context.Context, req *BaseRequest, opts ...http.CallOption) (rsp *AccountResponse, err error)
-	GetAllAccounts(ctx context.Context, req *EmptyRequest, opts ...http.CallOption) (rsp *GetAllAccountsResponse, err error)
+	GetAllAccounts(ctx context.Context, req *ListAccountsRequest, opts ...http.CallOption) (rsp *GetAllAccountsResponse, err error)
+	GetBalance(ctx context.Context, req *GetBalanceRequest, opts ...http.CallOption) (rsp *BalanceResponse, err error)
 	UpdateAccount(ctx context.Context, req *UpdateAccountRequest, opts ...http.CallOption) (rsp *AccountResponse, err error)
 }
 
@@ -207,7 +233,7 @@ func (c *AccountHTTPClientImpl) GetAccount(ctx context.Context, in *BaseRequest,
 	return &out, nil
 }
 
-func (c *AccountHTTPClientImpl) GetAllAccounts(ctx context.Context, in *EmptyRequest, opts ...http.CallOption) (*GetAllAccountsResponse, error) {
+func (c *AccountHTTPClientImpl) GetAllAccounts(ctx context.Context, in *ListAccountsRequest, opts ...http.CallOption) (*GetAllAccountsResponse, error) {
 	var out GetAllAccountsResponse
 	pattern := "/v1/account"
 	path := binding.EncodeURL(pattern, in, true)
@@ -220,6 +246,19 @@ func (c *AccountHTTPClientImpl) GetAllAccounts(ctx context.Context, in *EmptyReq
 	return &out, nil
 }
 
+func (c *AccountHTTPClientImpl) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...http.CallOption) (*BalanceResponse, error) {
+	var out BalanceResponse
+	pattern := "/v1/account/{id}/balance"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationAccountGetBalance))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *AccountHTTPClientImpl) UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...http.CallOption) (*AccountResponse, error) {
 	var out AccountResponse
 	pattern := "/v1/account/{id}"
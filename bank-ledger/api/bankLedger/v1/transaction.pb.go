@@ -28,6 +28,8 @@ const (
 	TransactionType_TRANSACTION_TYPE_UNSPECIFIED TransactionType = 0
 	TransactionType_DEPOSIT                      TransactionType = 1
 	TransactionType_WITHDRAWAL                   TransactionType = 2
+	TransactionType_FEE                          TransactionType = 3
+	TransactionType_TRANSFER                     TransactionType = 4
 )
 
 // Enum value maps for TransactionType.
@@ -36,11 +38,15 @@ var (
 		0: "TRANSACTION_TYPE_UNSPECIFIED",
 		1: "DEPOSIT",
 		2: "WITHDRAWAL",
+		3: "FEE",
+		4: "TRANSFER",
 	}
 	TransactionType_value = map[string]int32{
 		"TRANSACTION_TYPE_UNSPECIFIED": 0,
 		"DEPOSIT":                      1,
 		"WITHDRAWAL":                   2,
+		"FEE":                          3,
+		"TRANSFER":                     4,
 	}
 )
 
@@ -127,13 +133,15 @@ func (TransactionStatus) EnumDescriptor() ([]byte, []int) {
 }
 
 type CreateTransactionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
-	Type          TransactionType        `protobuf:"varint,3,opt,name=type,proto3,enum=bankLedger.v1.TransactionType" json:"type,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	AccountId             string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Amount                float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Type                  TransactionType        `protobuf:"varint,3,opt,name=type,proto3,enum=bankLedger.v1.TransactionType" json:"type,omitempty"`
+	Description           string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	CounterpartyAccountId string                 `protobuf:"bytes,5,opt,name=counterparty_account_id,json=counterpartyAccountId,proto3" json:"counterparty_account_id,omitempty"`
+	IdempotencyKey        string                 `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *CreateTransactionRequest) Reset() {
@@ -194,6 +202,20 @@ func (x *CreateTransactionRequest) GetDescription() string {
 	return ""
 }
 
+func (x *CreateTransactionRequest) GetCounterpartyAccountId() string {
+	if x != nil {
+		return x.CounterpartyAccountId
+	}
+	return ""
+}
+
+func (x *CreateTransactionRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type CreateTransactionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
@@ -307,18 +329,20 @@ func (x *GetTransactionByIdRequest) GetTransactionId() string {
 }
 
 type EachTransaction struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Type          TransactionType        `protobuf:"varint,4,opt,name=type,proto3,enum=bankLedger.v1.TransactionType" json:"type,omitempty"`
-	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
-	Status        TransactionStatus      `protobuf:"varint,7,opt,name=status,proto3,enum=bankLedger.v1.TransactionStatus" json:"status,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId             string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Amount                float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Type                  TransactionType        `protobuf:"varint,4,opt,name=type,proto3,enum=bankLedger.v1.TransactionType" json:"type,omitempty"`
+	Description           string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Currency              string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status                TransactionStatus      `protobuf:"varint,7,opt,name=status,proto3,enum=bankLedger.v1.TransactionStatus" json:"status,omitempty"`
+	CreatedAt             string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt             string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ParentTransactionId   string                 `protobuf:"bytes,10,opt,name=parent_transaction_id,json=parentTransactionId,proto3" json:"parent_transaction_id,omitempty"`
+	CounterpartyAccountId string                 `protobuf:"bytes,11,opt,name=counterparty_account_id,json=counterpartyAccountId,proto3" json:"counterparty_account_id,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *EachTransaction) Reset() {
@@ -414,6 +438,148 @@ func (x *EachTransaction) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *EachTransaction) GetParentTransactionId() string {
+	if x != nil {
+		return x.ParentTransactionId
+	}
+	return ""
+}
+
+func (x *EachTransaction) GetCounterpartyAccountId() string {
+	if x != nil {
+		return x.CounterpartyAccountId
+	}
+	return ""
+}
+
+type WatchTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTransactionRequest) Reset() {
+	*x = WatchTransactionRequest{}
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTransactionRequest) ProtoMessage() {}
+
+func (x *WatchTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTransactionRequest.ProtoReflect.Descriptor instead.
+func (*WatchTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WatchTransactionRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type TransactionEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Status        TransactionStatus      `protobuf:"varint,3,opt,name=status,proto3,enum=bankLedger.v1.TransactionStatus" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Attempt       int32                  `protobuf:"varint,5,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionEvent) Reset() {
+	*x = TransactionEvent{}
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionEvent) ProtoMessage() {}
+
+func (x *TransactionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionEvent.ProtoReflect.Descriptor instead.
+func (*TransactionEvent) Descriptor() ([]byte, []int) {
+	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TransactionEvent) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *TransactionEvent) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *TransactionEvent) GetStatus() TransactionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED
+}
+
+func (x *TransactionEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TransactionEvent) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *TransactionEvent) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
 type TransactionLog struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Timestamp     string                 `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
@@ -426,7 +592,7 @@ type TransactionLog struct {
 
 func (x *TransactionLog) Reset() {
 	*x = TransactionLog{}
-	mi := &file_bankLedger_v1_transaction_proto_msgTypes[4]
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -438,7 +604,7 @@ func (x *TransactionLog) String() string {
 func (*TransactionLog) ProtoMessage() {}
 
 func (x *TransactionLog) ProtoReflect() protoreflect.Message {
-	mi := &file_bankLedger_v1_transaction_proto_msgTypes[4]
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -451,7 +617,7 @@ func (x *TransactionLog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransactionLog.ProtoReflect.Descriptor instead.
 func (*TransactionLog) Descriptor() ([]byte, []int) {
-	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{4}
+	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *TransactionLog) GetTimestamp() string {
@@ -492,7 +658,7 @@ type GetTransactionResponse struct {
 
 func (x *GetTransactionResponse) Reset() {
 	*x = GetTransactionResponse{}
-	mi := &file_bankLedger_v1_transaction_proto_msgTypes[5]
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -504,7 +670,7 @@ func (x *GetTransactionResponse) String() string {
 func (*GetTransactionResponse) ProtoMessage() {}
 
 func (x *GetTransactionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bankLedger_v1_transaction_proto_msgTypes[5]
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -517,7 +683,7 @@ func (x *GetTransactionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTransactionResponse.ProtoReflect.Descriptor instead.
 func (*GetTransactionResponse) Descriptor() ([]byte, []int) {
-	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{5}
+	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetTransactionResponse) GetTransaction() *EachTransaction {
@@ -535,17 +701,33 @@ func (x *GetTransactionResponse) GetLogs() []*TransactionLog {
 }
 
 type GetTransactionsByAccountRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Deprecated: offset paging is replaced by page_token. Values above 1
+	// without a page_token are rejected.
+	//
+	// Deprecated: Marked as deprecated in bankLedger/v1/transaction.proto.
+	Page     int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque cursor returned as next_page_token by the previous call.
+	PageToken string              `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Types     []TransactionType   `protobuf:"varint,5,rep,packed,name=types,proto3,enum=bankLedger.v1.TransactionType" json:"types,omitempty"`
+	Statuses  []TransactionStatus `protobuf:"varint,6,rep,packed,name=statuses,proto3,enum=bankLedger.v1.TransactionStatus" json:"statuses,omitempty"`
+	MinAmount float64             `protobuf:"fixed64,7,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	MaxAmount float64             `protobuf:"fixed64,8,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	// RFC3339 timestamp or YYYY-MM-DD date, inclusive.
+	From string `protobuf:"bytes,9,opt,name=from,proto3" json:"from,omitempty"`
+	// RFC3339 timestamp or YYYY-MM-DD date, exclusive for timestamps and inclusive for dates.
+	To string `protobuf:"bytes,10,opt,name=to,proto3" json:"to,omitempty"`
+	// Case-insensitive substring match on the description.
+	Description   string `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetTransactionsByAccountRequest) Reset() {
 	*x = GetTransactionsByAccountRequest{}
-	mi := &file_bankLedger_v1_transaction_proto_msgTypes[6]
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -557,7 +739,7 @@ func (x *GetTransactionsByAccountRequest) String() string {
 func (*GetTransactionsByAccountRequest) ProtoMessage() {}
 
 func (x *GetTransactionsByAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bankLedger_v1_transaction_proto_msgTypes[6]
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,7 +752,7 @@ func (x *GetTransactionsByAccountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTransactionsByAccountRequest.ProtoReflect.Descriptor instead.
 func (*GetTransactionsByAccountRequest) Descriptor() ([]byte, []int) {
-	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{6}
+	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetTransactionsByAccountRequest) GetAccountId() string {
@@ -580,6 +762,7 @@ func (x *GetTransactionsByAccountRequest) GetAccountId() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in bankLedger/v1/transaction.proto.
 func (x *GetTransactionsByAccountRequest) GetPage() int32 {
 	if x != nil {
 		return x.Page
@@ -594,19 +777,86 @@ func (x *GetTransactionsByAccountRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *GetTransactionsByAccountRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetTransactionsByAccountRequest) GetTypes() []TransactionType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *GetTransactionsByAccountRequest) GetStatuses() []TransactionStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *GetTransactionsByAccountRequest) GetMinAmount() float64 {
+	if x != nil {
+		return x.MinAmount
+	}
+	return 0
+}
+
+func (x *GetTransactionsByAccountRequest) GetMaxAmount() float64 {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return 0
+}
+
+func (x *GetTransactionsByAccountRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetTransactionsByAccountRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GetTransactionsByAccountRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
 type PaginationInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TotalCount    int32                  `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	TotalPages    int32                  `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: totals are no longer counted for cursor paging.
+	//
+	// Deprecated: Marked as deprecated in bankLedger/v1/transaction.proto.
+	TotalCount int32 `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	PageSize   int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Deprecated: offset paging is replaced by next_page_token.
+	//
+	// Deprecated: Marked as deprecated in bankLedger/v1/transaction.proto.
+	Page int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	// Deprecated: totals are no longer counted for cursor paging.
+	//
+	// Deprecated: Marked as deprecated in bankLedger/v1/transaction.proto.
+	TotalPages int32 `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	// Empty when there are no more results.
+	NextPageToken string `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PaginationInfo) Reset() {
 	*x = PaginationInfo{}
-	mi := &file_bankLedger_v1_transaction_proto_msgTypes[7]
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -618,7 +868,7 @@ func (x *PaginationInfo) String() string {
 func (*PaginationInfo) ProtoMessage() {}
 
 func (x *PaginationInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_bankLedger_v1_transaction_proto_msgTypes[7]
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -631,9 +881,10 @@ func (x *PaginationInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PaginationInfo.ProtoReflect.Descriptor instead.
 func (*PaginationInfo) Descriptor() ([]byte, []int) {
-	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{7}
+	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{9}
 }
 
+// Deprecated: Marked as deprecated in bankLedger/v1/transaction.proto.
 func (x *PaginationInfo) GetTotalCount() int32 {
 	if x != nil {
 		return x.TotalCount
@@ -648,6 +899,7 @@ func (x *PaginationInfo) GetPageSize() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in bankLedger/v1/transaction.proto.
 func (x *PaginationInfo) GetPage() int32 {
 	if x != nil {
 		return x.Page
@@ -655,6 +907,7 @@ func (x *PaginationInfo) GetPage() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in bankLedger/v1/transaction.proto.
 func (x *PaginationInfo) GetTotalPages() int32 {
 	if x != nil {
 		return x.TotalPages
@@ -662,6 +915,13 @@ func (x *PaginationInfo) GetTotalPages() int32 {
 	return 0
 }
 
+func (x *PaginationInfo) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type AccountInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -674,7 +934,7 @@ type AccountInfo struct {
 
 func (x *AccountInfo) Reset() {
 	*x = AccountInfo{}
-	mi := &file_bankLedger_v1_transaction_proto_msgTypes[8]
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -686,7 +946,7 @@ func (x *AccountInfo) String() string {
 func (*AccountInfo) ProtoMessage() {}
 
 func (x *AccountInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_bankLedger_v1_transaction_proto_msgTypes[8]
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -699,7 +959,7 @@ func (x *AccountInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AccountInfo.ProtoReflect.Descriptor instead.
 func (*AccountInfo) Descriptor() ([]byte, []int) {
-	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{8}
+	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AccountInfo) GetId() string {
@@ -742,7 +1002,7 @@ type GetTransactionsByAccountResponse struct {
 
 func (x *GetTransactionsByAccountResponse) Reset() {
 	*x = GetTransactionsByAccountResponse{}
-	mi := &file_bankLedger_v1_transaction_proto_msgTypes[9]
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -754,7 +1014,7 @@ func (x *GetTransactionsByAccountResponse) String() string {
 func (*GetTransactionsByAccountResponse) ProtoMessage() {}
 
 func (x *GetTransactionsByAccountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bankLedger_v1_transaction_proto_msgTypes[9]
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -767,7 +1027,7 @@ func (x *GetTransactionsByAccountResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTransactionsByAccountResponse.ProtoReflect.Descriptor instead.
 func (*GetTransactionsByAccountResponse) Descriptor() ([]byte, []int) {
-	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{9}
+	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetTransactionsByAccountResponse) GetAccountId() string {
@@ -798,17 +1058,469 @@ func (x *GetTransactionsByAccountResponse) GetAccountInfo() *AccountInfo {
 	return nil
 }
 
+type GetStatementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Format        string                 `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatementRequest) Reset() {
+	*x = GetStatementRequest{}
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatementRequest) ProtoMessage() {}
+
+func (x *GetStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatementRequest.ProtoReflect.Descriptor instead.
+func (*GetStatementRequest) Descriptor() ([]byte, []int) {
+	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetStatementRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetStatementRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetStatementRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GetStatementRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type StatementLine struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId         string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	BookedAt              string                 `protobuf:"bytes,2,opt,name=booked_at,json=bookedAt,proto3" json:"booked_at,omitempty"`
+	Type                  TransactionType        `protobuf:"varint,3,opt,name=type,proto3,enum=bankLedger.v1.TransactionType" json:"type,omitempty"`
+	Description           string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Amount                float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	RunningBalance        float64                `protobuf:"fixed64,6,opt,name=running_balance,json=runningBalance,proto3" json:"running_balance,omitempty"`
+	ParentTransactionId   string                 `protobuf:"bytes,7,opt,name=parent_transaction_id,json=parentTransactionId,proto3" json:"parent_transaction_id,omitempty"`
+	CounterpartyAccountId string                 `protobuf:"bytes,8,opt,name=counterparty_account_id,json=counterpartyAccountId,proto3" json:"counterparty_account_id,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *StatementLine) Reset() {
+	*x = StatementLine{}
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatementLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatementLine) ProtoMessage() {}
+
+func (x *StatementLine) ProtoReflect() protoreflect.Message {
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatementLine.ProtoReflect.Descriptor instead.
+func (*StatementLine) Descriptor() ([]byte, []int) {
+	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StatementLine) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *StatementLine) GetBookedAt() string {
+	if x != nil {
+		return x.BookedAt
+	}
+	return ""
+}
+
+func (x *StatementLine) GetType() TransactionType {
+	if x != nil {
+		return x.Type
+	}
+	return TransactionType_TRANSACTION_TYPE_UNSPECIFIED
+}
+
+func (x *StatementLine) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StatementLine) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *StatementLine) GetRunningBalance() float64 {
+	if x != nil {
+		return x.RunningBalance
+	}
+	return 0
+}
+
+func (x *StatementLine) GetParentTransactionId() string {
+	if x != nil {
+		return x.ParentTransactionId
+	}
+	return ""
+}
+
+func (x *StatementLine) GetCounterpartyAccountId() string {
+	if x != nil {
+		return x.CounterpartyAccountId
+	}
+	return ""
+}
+
+type GetStatementResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountId      string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AccountNumber  string                 `protobuf:"bytes,2,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	AccountName    string                 `protobuf:"bytes,3,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Currency       string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	From           string                 `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`
+	To             string                 `protobuf:"bytes,6,opt,name=to,proto3" json:"to,omitempty"`
+	OpeningBalance float64                `protobuf:"fixed64,7,opt,name=opening_balance,json=openingBalance,proto3" json:"opening_balance,omitempty"`
+	ClosingBalance float64                `protobuf:"fixed64,8,opt,name=closing_balance,json=closingBalance,proto3" json:"closing_balance,omitempty"`
+	TotalCredits   float64                `protobuf:"fixed64,9,opt,name=total_credits,json=totalCredits,proto3" json:"total_credits,omitempty"`
+	TotalDebits    float64                `protobuf:"fixed64,10,opt,name=total_debits,json=totalDebits,proto3" json:"total_debits,omitempty"`
+	Lines          []*StatementLine       `protobuf:"bytes,11,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetStatementResponse) Reset() {
+	*x = GetStatementResponse{}
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatementResponse) ProtoMessage() {}
+
+func (x *GetStatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatementResponse.ProtoReflect.Descriptor instead.
+func (*GetStatementResponse) Descriptor() ([]byte, []int) {
+	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetStatementResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetStatementResponse) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *GetStatementResponse) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *GetStatementResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GetStatementResponse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetStatementResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GetStatementResponse) GetOpeningBalance() float64 {
+	if x != nil {
+		return x.OpeningBalance
+	}
+	return 0
+}
+
+func (x *GetStatementResponse) GetClosingBalance() float64 {
+	if x != nil {
+		return x.ClosingBalance
+	}
+	return 0
+}
+
+func (x *GetStatementResponse) GetTotalCredits() float64 {
+	if x != nil {
+		return x.TotalCredits
+	}
+	return 0
+}
+
+func (x *GetStatementResponse) GetTotalDebits() float64 {
+	if x != nil {
+		return x.TotalDebits
+	}
+	return 0
+}
+
+func (x *GetStatementResponse) GetLines() []*StatementLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+// LogDiscrepancy is a transaction whose MongoDB log document is missing or
+// disagrees with its MySQL row.
+type LogDiscrepancy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// One of missing, status_mismatch or orphaned (a log document without a
+	// MySQL row).
+	Kind        string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	MysqlStatus string `protobuf:"bytes,3,opt,name=mysql_status,json=mysqlStatus,proto3" json:"mysql_status,omitempty"`
+	MongoStatus string `protobuf:"bytes,4,opt,name=mongo_status,json=mongoStatus,proto3" json:"mongo_status,omitempty"`
+	// Whether the log document was repaired from the MySQL row.
+	Repaired      bool `protobuf:"varint,5,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogDiscrepancy) Reset() {
+	*x = LogDiscrepancy{}
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogDiscrepancy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogDiscrepancy) ProtoMessage() {}
+
+func (x *LogDiscrepancy) ProtoReflect() protoreflect.Message {
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogDiscrepancy.ProtoReflect.Descriptor instead.
+func (*LogDiscrepancy) Descriptor() ([]byte, []int) {
+	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LogDiscrepancy) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *LogDiscrepancy) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *LogDiscrepancy) GetMysqlStatus() string {
+	if x != nil {
+		return x.MysqlStatus
+	}
+	return ""
+}
+
+func (x *LogDiscrepancy) GetMongoStatus() string {
+	if x != nil {
+		return x.MongoStatus
+	}
+	return ""
+}
+
+func (x *LogDiscrepancy) GetRepaired() bool {
+	if x != nil {
+		return x.Repaired
+	}
+	return false
+}
+
+// LogConsistencyReport is written by the transaction log consistency checker.
+type LogConsistencyReport struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	StartedAt           string                 `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt          string                 `protobuf:"bytes,2,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	TransactionsChecked int32                  `protobuf:"varint,3,opt,name=transactions_checked,json=transactionsChecked,proto3" json:"transactions_checked,omitempty"`
+	LogDocumentsChecked int32                  `protobuf:"varint,4,opt,name=log_documents_checked,json=logDocumentsChecked,proto3" json:"log_documents_checked,omitempty"`
+	Discrepancies       []*LogDiscrepancy      `protobuf:"bytes,5,rep,name=discrepancies,proto3" json:"discrepancies,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *LogConsistencyReport) Reset() {
+	*x = LogConsistencyReport{}
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogConsistencyReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogConsistencyReport) ProtoMessage() {}
+
+func (x *LogConsistencyReport) ProtoReflect() protoreflect.Message {
+	mi := &file_bankLedger_v1_transaction_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogConsistencyReport.ProtoReflect.Descriptor instead.
+func (*LogConsistencyReport) Descriptor() ([]byte, []int) {
+	return file_bankLedger_v1_transaction_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *LogConsistencyReport) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *LogConsistencyReport) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+func (x *LogConsistencyReport) GetTransactionsChecked() int32 {
+	if x != nil {
+		return x.TransactionsChecked
+	}
+	return 0
+}
+
+func (x *LogConsistencyReport) GetLogDocumentsChecked() int32 {
+	if x != nil {
+		return x.LogDocumentsChecked
+	}
+	return 0
+}
+
+func (x *LogConsistencyReport) GetDiscrepancies() []*LogDiscrepancy {
+	if x != nil {
+		return x.Discrepancies
+	}
+	return nil
+}
+
 var File_bankLedger_v1_transaction_proto protoreflect.FileDescriptor
 
 const file_bankLedger_v1_transaction_proto_rawDesc = "" +
 	"\n" +
-	"\x1fbankLedger/v1/transaction.proto\x12\rbankLedger.v1\x1a\x1cgoogle/api/annotations.proto\"\xa7\x01\n" +
+	"\x1fbankLedger/v1/transaction.proto\x12\rbankLedger.v1\x1a\x1cgoogle/api/annotations.proto\"\x88\x02\n" +
 	"\x18CreateTransactionRequest\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
 	"\x06amount\x18\x02 \x01(\x01R\x06amount\x122\n" +
 	"\x04type\x18\x03 \x01(\x0e2\x1e.bankLedger.v1.TransactionTypeR\x04type\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\"\xba\x01\n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x126\n" +
+	"\x17counterparty_account_id\x18\x05 \x01(\tR\x15counterpartyAccountId\x12'\n" +
+	"\x0fidempotency_key\x18\x06 \x01(\tR\x0eidempotencyKey\"\xba\x01\n" +
 	"\x19CreateTransactionResponse\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x1d\n" +
 	"\n" +
@@ -817,7 +1529,7 @@ const file_bankLedger_v1_transaction_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x04 \x01(\tR\tcreatedAt\"B\n" +
 	"\x19GetTransactionByIdRequest\x12%\n" +
-	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\xc2\x02\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\xae\x03\n" +
 	"\x0fEachTransaction\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -830,7 +1542,20 @@ const file_bankLedger_v1_transaction_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\tR\tupdatedAt\"z\n" +
+	"updated_at\x18\t \x01(\tR\tupdatedAt\x122\n" +
+	"\x15parent_transaction_id\x18\n" +
+	" \x01(\tR\x13parentTransactionId\x126\n" +
+	"\x17counterparty_account_id\x18\v \x01(\tR\x15counterpartyAccountId\"@\n" +
+	"\x17WatchTransactionRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\xe4\x01\n" +
+	"\x10TransactionEvent\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x128\n" +
+	"\x06status\x18\x03 \x01(\x0e2 .bankLedger.v1.TransactionStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x18\n" +
+	"\aattempt\x18\x05 \x01(\x05R\aattempt\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\tR\ttimestamp\"z\n" +
 	"\x0eTransactionLog\x12\x1c\n" +
 	"\ttimestamp\x18\x01 \x01(\tR\ttimestamp\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
@@ -838,19 +1563,32 @@ const file_bankLedger_v1_transaction_proto_rawDesc = "" +
 	"\aattempt\x18\x04 \x01(\x05R\aattempt\"\x8d\x01\n" +
 	"\x16GetTransactionResponse\x12@\n" +
 	"\vtransaction\x18\x01 \x01(\v2\x1e.bankLedger.v1.EachTransactionR\vtransaction\x121\n" +
-	"\x04logs\x18\x02 \x03(\v2\x1d.bankLedger.v1.TransactionLogR\x04logs\"q\n" +
+	"\x04logs\x18\x02 \x03(\v2\x1d.bankLedger.v1.TransactionLogR\x04logs\"\x8c\x03\n" +
 	"\x1fGetTransactionsByAccountRequest\x12\x1d\n" +
 	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
-	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x83\x01\n" +
-	"\x0ePaginationInfo\x12\x1f\n" +
-	"\vtotal_count\x18\x01 \x01(\x05R\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
+	"\x04page\x18\x02 \x01(\x05B\x02\x18\x01R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x124\n" +
+	"\x05types\x18\x05 \x03(\x0e2\x1e.bankLedger.v1.TransactionTypeR\x05types\x12<\n" +
+	"\bstatuses\x18\x06 \x03(\x0e2 .bankLedger.v1.TransactionStatusR\bstatuses\x12\x1d\n" +
+	"\n" +
+	"min_amount\x18\a \x01(\x01R\tminAmount\x12\x1d\n" +
+	"\n" +
+	"max_amount\x18\b \x01(\x01R\tmaxAmount\x12\x12\n" +
+	"\x04from\x18\t \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\n" +
+	" \x01(\tR\x02to\x12 \n" +
+	"\vdescription\x18\v \x01(\tR\vdescription\"\xb7\x01\n" +
+	"\x0ePaginationInfo\x12#\n" +
+	"\vtotal_count\x18\x01 \x01(\x05B\x02\x18\x01R\n" +
 	"totalCount\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x12\n" +
-	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1f\n" +
-	"\vtotal_pages\x18\x04 \x01(\x05R\n" +
-	"totalPages\"k\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x04page\x18\x03 \x01(\x05B\x02\x18\x01R\x04page\x12#\n" +
+	"\vtotal_pages\x18\x04 \x01(\x05B\x02\x18\x01R\n" +
+	"totalPages\x12&\n" +
+	"\x0fnext_page_token\x18\x05 \x01(\tR\rnextPageToken\"k\n" +
 	"\vAccountInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\abalance\x18\x02 \x01(\x01R\abalance\x12\x1a\n" +
@@ -863,12 +1601,57 @@ const file_bankLedger_v1_transaction_proto_rawDesc = "" +
 	"\n" +
 	"pagination\x18\x03 \x01(\v2\x1d.bankLedger.v1.PaginationInfoR\n" +
 	"pagination\x12=\n" +
-	"\faccount_info\x18\x04 \x01(\v2\x1a.bankLedger.v1.AccountInfoR\vaccountInfo*P\n" +
+	"\faccount_info\x18\x04 \x01(\v2\x1a.bankLedger.v1.AccountInfoR\vaccountInfo\"p\n" +
+	"\x13GetStatementRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\"\xd6\x02\n" +
+	"\rStatementLine\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x1b\n" +
+	"\tbooked_at\x18\x02 \x01(\tR\bbookedAt\x122\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1e.bankLedger.v1.TransactionTypeR\x04type\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12'\n" +
+	"\x0frunning_balance\x18\x06 \x01(\x01R\x0erunningBalance\x122\n" +
+	"\x15parent_transaction_id\x18\a \x01(\tR\x13parentTransactionId\x126\n" +
+	"\x17counterparty_account_id\x18\b \x01(\tR\x15counterpartyAccountId\"\x8d\x03\n" +
+	"\x14GetStatementResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12%\n" +
+	"\x0eaccount_number\x18\x02 \x01(\tR\raccountNumber\x12!\n" +
+	"\faccount_name\x18\x03 \x01(\tR\vaccountName\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04from\x18\x05 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x06 \x01(\tR\x02to\x12'\n" +
+	"\x0fopening_balance\x18\a \x01(\x01R\x0eopeningBalance\x12'\n" +
+	"\x0fclosing_balance\x18\b \x01(\x01R\x0eclosingBalance\x12#\n" +
+	"\rtotal_credits\x18\t \x01(\x01R\ftotalCredits\x12!\n" +
+	"\ftotal_debits\x18\n" +
+	" \x01(\x01R\vtotalDebits\x122\n" +
+	"\x05lines\x18\v \x03(\v2\x1c.bankLedger.v1.StatementLineR\x05lines\"\xad\x01\n" +
+	"\x0eLogDiscrepancy\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12!\n" +
+	"\fmysql_status\x18\x03 \x01(\tR\vmysqlStatus\x12!\n" +
+	"\fmongo_status\x18\x04 \x01(\tR\vmongoStatus\x12\x1a\n" +
+	"\brepaired\x18\x05 \x01(\bR\brepaired\"\x82\x02\n" +
+	"\x14LogConsistencyReport\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x01 \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x02 \x01(\tR\n" +
+	"finishedAt\x121\n" +
+	"\x14transactions_checked\x18\x03 \x01(\x05R\x13transactionsChecked\x122\n" +
+	"\x15log_documents_checked\x18\x04 \x01(\x05R\x13logDocumentsChecked\x12C\n" +
+	"\rdiscrepancies\x18\x05 \x03(\v2\x1d.bankLedger.v1.LogDiscrepancyR\rdiscrepancies*g\n" +
 	"\x0fTransactionType\x12 \n" +
 	"\x1cTRANSACTION_TYPE_UNSPECIFIED\x10\x00\x12\v\n" +
 	"\aDEPOSIT\x10\x01\x12\x0e\n" +
 	"\n" +
-	"WITHDRAWAL\x10\x02*o\n" +
+	"WITHDRAWAL\x10\x02\x12\a\n" +
+	"\x03FEE\x10\x03\x12\f\n" +
+	"\bTRANSFER\x10\x04*o\n" +
 	"\x11TransactionStatus\x12\"\n" +
 	"\x1eTRANSACTION_STATUS_UNSPECIFIED\x10\x00\x12\r\n" +
 	"\tINITIATED\x10\x01\x12\x0e\n" +
@@ -876,11 +1659,13 @@ const file_bankLedger_v1_transaction_proto_rawDesc = "" +
 	"PROCESSING\x10\x02\x12\v\n" +
 	"\aSUCCESS\x10\x03\x12\n" +
 	"\n" +
-	"\x06FAILED\x10\x042\xd1\x03\n" +
+	"\x06FAILED\x10\x042\xb6\x05\n" +
 	"\vTransaction\x12\x82\x01\n" +
 	"\x11CreateTransaction\x12'.bankLedger.v1.CreateTransactionRequest\x1a(.bankLedger.v1.CreateTransactionResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/transaction\x12\x8f\x01\n" +
 	"\x12GetTransactionById\x12(.bankLedger.v1.GetTransactionByIdRequest\x1a%.bankLedger.v1.GetTransactionResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /v1/transaction/{transaction_id}\x12\xaa\x01\n" +
-	"\x18GetTransactionsByAccount\x12..bankLedger.v1.GetTransactionsByAccountRequest\x1a/.bankLedger.v1.GetTransactionsByAccountResponse\"-\x82\xd3\xe4\x93\x02'\x12%/v1/account/{account_id}/transactionsB]\n" +
+	"\x18GetTransactionsByAccount\x12..bankLedger.v1.GetTransactionsByAccountRequest\x1a/.bankLedger.v1.GetTransactionsByAccountResponse\"-\x82\xd3\xe4\x93\x02'\x12%/v1/account/{account_id}/transactions\x12\x83\x01\n" +
+	"\fGetStatement\x12\".bankLedger.v1.GetStatementRequest\x1a#.bankLedger.v1.GetStatementResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/v1/account/{account_id}/statement\x12]\n" +
+	"\x10WatchTransaction\x12&.bankLedger.v1.WatchTransactionRequest\x1a\x1f.bankLedger.v1.TransactionEvent0\x01B]\n" +
 	"\x1cdev.kratos.api.bankLedger.v1B\x11BankLedgerProtoV1P\x01Z(bank-ledger-service/api/bankLedger/v1;v1b\x06proto3"
 
 var (
@@ -896,7 +1681,7 @@ func file_bankLedger_v1_transaction_proto_rawDescGZIP() []byte {
 }
 
 var file_bankLedger_v1_transaction_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_bankLedger_v1_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_bankLedger_v1_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_bankLedger_v1_transaction_proto_goTypes = []any{
 	(TransactionType)(0),                     // 0: bankLedger.v1.TransactionType
 	(TransactionStatus)(0),                   // 1: bankLedger.v1.TransactionStatus
@@ -904,34 +1689,51 @@ var file_bankLedger_v1_transaction_proto_goTypes = []any{
 	(*CreateTransactionResponse)(nil),        // 3: bankLedger.v1.CreateTransactionResponse
 	(*GetTransactionByIdRequest)(nil),        // 4: bankLedger.v1.GetTransactionByIdRequest
 	(*EachTransaction)(nil),                  // 5: bankLedger.v1.EachTransaction
-	(*TransactionLog)(nil),                   // 6: bankLedger.v1.TransactionLog
-	(*GetTransactionResponse)(nil),           // 7: bankLedger.v1.GetTransactionResponse
-	(*GetTransactionsByAccountRequest)(nil),  // 8: bankLedger.v1.GetTransactionsByAccountRequest
-	(*PaginationInfo)(nil),                   // 9: bankLedger.v1.PaginationInfo
-	(*AccountInfo)(nil),                      // 10: bankLedger.v1.AccountInfo
-	(*GetTransactionsByAccountResponse)(nil), // 11: bankLedger.v1.GetTransactionsByAccountResponse
+	(*WatchTransactionRequest)(nil),          // 6: bankLedger.v1.WatchTransactionRequest
+	(*TransactionEvent)(nil),                 // 7: bankLedger.v1.TransactionEvent
+	(*TransactionLog)(nil),                   // 8: bankLedger.v1.TransactionLog
+	(*GetTransactionResponse)(nil),           // 9: bankLedger.v1.GetTransactionResponse
+	(*GetTransactionsByAccountRequest)(nil),  // 10: bankLedger.v1.GetTransactionsByAccountRequest
+	(*PaginationInfo)(nil),                   // 11: bankLedger.v1.PaginationInfo
+	(*AccountInfo)(nil),                      // 12: bankLedger.v1.AccountInfo
+	(*GetTransactionsByAccountResponse)(nil), // 13: bankLedger.v1.GetTransactionsByAccountResponse
+	(*GetStatementRequest)(nil),              // 14: bankLedger.v1.GetStatementRequest
+	(*StatementLine)(nil),                    // 15: bankLedger.v1.StatementLine
+	(*GetStatementResponse)(nil),             // 16: bankLedger.v1.GetStatementResponse
+	(*LogDiscrepancy)(nil),                   // 17: bankLedger.v1.LogDiscrepancy
+	(*LogConsistencyReport)(nil),             // 18: bankLedger.v1.LogConsistencyReport
 }
 var file_bankLedger_v1_transaction_proto_depIdxs = []int32{
 	0,  // 0: bankLedger.v1.CreateTransactionRequest.type:type_name -> bankLedger.v1.TransactionType
 	1,  // 1: bankLedger.v1.CreateTransactionResponse.status:type_name -> bankLedger.v1.TransactionStatus
 	0,  // 2: bankLedger.v1.EachTransaction.type:type_name -> bankLedger.v1.TransactionType
 	1,  // 3: bankLedger.v1.EachTransaction.status:type_name -> bankLedger.v1.TransactionStatus
-	5,  // 4: bankLedger.v1.GetTransactionResponse.transaction:type_name -> bankLedger.v1.EachTransaction
-	6,  // 5: bankLedger.v1.GetTransactionResponse.logs:type_name -> bankLedger.v1.TransactionLog
-	5,  // 6: bankLedger.v1.GetTransactionsByAccountResponse.transactions:type_name -> bankLedger.v1.EachTransaction
-	9,  // 7: bankLedger.v1.GetTransactionsByAccountResponse.pagination:type_name -> bankLedger.v1.PaginationInfo
-	10, // 8: bankLedger.v1.GetTransactionsByAccountResponse.account_info:type_name -> bankLedger.v1.AccountInfo
-	2,  // 9: bankLedger.v1.Transaction.CreateTransaction:input_type -> bankLedger.v1.CreateTransactionRequest
-	4,  // 10: bankLedger.v1.Transaction.GetTransactionById:input_type -> bankLedger.v1.GetTransactionByIdRequest
-	8,  // 11: bankLedger.v1.Transaction.GetTransactionsByAccount:input_type -> bankLedger.v1.GetTransactionsByAccountRequest
-	3,  // 12: bankLedger.v1.Transaction.CreateTransaction:output_type -> bankLedger.v1.CreateTransactionResponse
-	7,  // 13: bankLedger.v1.Transaction.GetTransactionById:output_type -> bankLedger.v1.GetTransactionResponse
-	11, // 14: bankLedger.v1.Transaction.GetTransactionsByAccount:output_type -> bankLedger.v1.GetTransactionsByAccountResponse
-	12, // [12:15] is the sub-list for method output_type
-	9,  // [9:12] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	1,  // 4: bankLedger.v1.TransactionEvent.status:type_name -> bankLedger.v1.TransactionStatus
+	5,  // 5: bankLedger.v1.GetTransactionResponse.transaction:type_name -> bankLedger.v1.EachTransaction
+	8,  // 6: bankLedger.v1.GetTransactionResponse.logs:type_name -> bankLedger.v1.TransactionLog
+	0,  // 7: bankLedger.v1.GetTransactionsByAccountRequest.types:type_name -> bankLedger.v1.TransactionType
+	1,  // 8: bankLedger.v1.GetTransactionsByAccountRequest.statuses:type_name -> bankLedger.v1.TransactionStatus
+	5,  // 9: bankLedger.v1.GetTransactionsByAccountResponse.transactions:type_name -> bankLedger.v1.EachTransaction
+	11, // 10: bankLedger.v1.GetTransactionsByAccountResponse.pagination:type_name -> bankLedger.v1.PaginationInfo
+	12, // 11: bankLedger.v1.GetTransactionsByAccountResponse.account_info:type_name -> bankLedger.v1.AccountInfo
+	0,  // 12: bankLedger.v1.StatementLine.type:type_name -> bankLedger.v1.TransactionType
+	15, // 13: bankLedger.v1.GetStatementResponse.lines:type_name -> bankLedger.v1.StatementLine
+	17, // 14: bankLedger.v1.LogConsistencyReport.discrepancies:type_name -> bankLedger.v1.LogDiscrepancy
+	2,  // 15: bankLedger.v1.Transaction.CreateTransaction:input_type -> bankLedger.v1.CreateTransactionRequest
+	4,  // 16: bankLedger.v1.Transaction.GetTransactionById:input_type -> bankLedger.v1.GetTransactionByIdRequest
+	10, // 17: bankLedger.v1.Transaction.GetTransactionsByAccount:input_type -> bankLedger.v1.GetTransactionsByAccountRequest
+	14, // 18: bankLedger.v1.Transaction.GetStatement:input_type -> bankLedger.v1.GetStatementRequest
+	6,  // 19: bankLedger.v1.Transaction.WatchTransaction:input_type -> bankLedger.v1.WatchTransactionRequest
+	3,  // 20: bankLedger.v1.Transaction.CreateTransaction:output_type -> bankLedger.v1.CreateTransactionResponse
+	9,  // 21: bankLedger.v1.Transaction.GetTransactionById:output_type -> bankLedger.v1.GetTransactionResponse
+	13, // 22: bankLedger.v1.Transaction.GetTransactionsByAccount:output_type -> bankLedger.v1.GetTransactionsByAccountResponse
+	16, // 23: bankLedger.v1.Transaction.GetStatement:output_type -> bankLedger.v1.GetStatementResponse
+	7,  // 24: bankLedger.v1.Transaction.WatchTransaction:output_type -> bankLedger.v1.TransactionEvent
+	20, // [20:25] is the sub-list for method output_type
+	15, // [15:20] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_bankLedger_v1_transaction_proto_init() }
@@ -945,7 +1747,7 @@ func file_bankLedger_v1_transaction_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bankLedger_v1_transaction_proto_rawDesc), len(file_bankLedger_v1_transaction_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   10,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
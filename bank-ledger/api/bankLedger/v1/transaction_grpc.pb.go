@@ -22,6 +22,8 @@ const (
 	Transaction_CreateTransaction_FullMethodName        = "/bankLedger.v1.Transaction/CreateTransaction"
 	Transaction_GetTransactionById_FullMethodName       = "/bankLedger.v1.Transaction/GetTransactionById"
 	Transaction_GetTransactionsByAccount_FullMethodName = "/bankLedger.v1.Transaction/GetTransactionsByAccount"
+	Transaction_GetStatement_FullMethodName             = "/bankLedger.v1.Transaction/GetStatement"
+	Transaction_WatchTransaction_FullMethodName         = "/bankLedger.v1.Transaction/WatchTransaction"
 )
 
 // TransactionClient is the client API for Transaction service.
@@ -31,6 +33,11 @@ type TransactionClient interface {
 	CreateTransaction(ctx context.Context, in *CreateTransactionRequest, opts ...grpc.CallOption) (*CreateTransactionResponse, error)
 	GetTransactionById(ctx context.Context, in *GetTransactionByIdRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
 	GetTransactionsByAccount(ctx context.Context, in *GetTransactionsByAccountRequest, opts ...grpc.CallOption) (*GetTransactionsByAccountResponse, error)
+	GetStatement(ctx context.Context, in *GetStatementRequest, opts ...grpc.CallOption) (*GetStatementResponse, error)
+	// Streams the transaction's recorded history followed by every status change
+	// and log entry until it reaches SUCCESS or FAILED. Served over HTTP as
+	// server-sent events on GET /v1/transaction/{transaction_id}/events.
+	WatchTransaction(ctx context.Context, in *WatchTransactionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TransactionEvent], error)
 }
 
 type transactionClient struct {
@@ -71,6 +78,35 @@ func (c *transactionClient) GetTransactionsByAccount(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *transactionClient) GetStatement(ctx context.Context, in *GetStatementRequest, opts ...grpc.CallOption) (*GetStatementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatementResponse)
+	err := c.cc.Invoke(ctx, Transaction_GetStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionClient) WatchTransaction(ctx context.Context, in *WatchTransactionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TransactionEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Transaction_ServiceDesc.Streams[0], Transaction_WatchTransaction_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchTransactionRequest, TransactionEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Transaction_WatchTransactionClient = grpc.ServerStreamingClient[TransactionEvent]
+
 // TransactionServer is the server API for Transaction service.
 // All implementations must embed UnimplementedTransactionServer
 // for forward compatibility.
@@ -78,6 +114,11 @@ type TransactionServer interface {
 	CreateTransaction(context.Context, *CreateTransactionRequest) (*CreateTransactionResponse, error)
 	GetTransactionById(context.Context, *GetTransactionByIdRequest) (*GetTransactionResponse, error)
 	GetTransactionsByAccount(context.Context, *GetTransactionsByAccountRequest) (*GetTransactionsByAccountResponse, error)
+	GetStatement(context.Context, *GetStatementRequest) (*GetStatementResponse, error)
+	// Streams the transaction's recorded history followed by every status change
+	// and log entry until it reaches SUCCESS or FAILED. Served over HTTP as
+	// server-sent events on GET /v1/transaction/{transaction_id}/events.
+	WatchTransaction(*WatchTransactionRequest, grpc.ServerStreamingServer[TransactionEvent]) error
 	mustEmbedUnimplementedTransactionServer()
 }
 
@@ -97,6 +138,12 @@ func (UnimplementedTransactionServer) GetTransactionById(context.Context, *GetTr
 func (UnimplementedTransactionServer) GetTransactionsByAccount(context.Context, *GetTransactionsByAccountRequest) (*GetTransactionsByAccountResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionsByAccount not implemented")
 }
+func (UnimplementedTransactionServer) GetStatement(context.Context, *GetStatementRequest) (*GetStatementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatement not implemented")
+}
+func (UnimplementedTransactionServer) WatchTransaction(*WatchTransactionRequest, grpc.ServerStreamingServer[TransactionEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTransaction not implemented")
+}
 func (UnimplementedTransactionServer) mustEmbedUnimplementedTransactionServer() {}
 func (UnimplementedTransactionServer) testEmbeddedByValue()                     {}
 
@@ -172,6 +219,35 @@ func _Transaction_GetTransactionsByAccount_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Transaction_GetStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServer).GetStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Transaction_GetStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServer).GetStatement(ctx, req.(*GetStatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Transaction_WatchTransaction_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTransactionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TransactionServer).WatchTransaction(m, &grpc.GenericServerStream[WatchTransactionRequest, TransactionEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Transaction_WatchTransactionServer = grpc.ServerStreamingServer[TransactionEvent]
+
 // Transaction_ServiceDesc is the grpc.ServiceDesc for Transaction service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -191,7 +267,17 @@ var Transaction_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransactionsByAccount",
 			Handler:    _Transaction_GetTransactionsByAccount_Handler,
 		},
+		{
+			MethodName: "GetStatement",
+			Handler:    _Transaction_GetStatement_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTransaction",
+			Handler:       _Transaction_WatchTransaction_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "bankLedger/v1/transaction.proto",
 }
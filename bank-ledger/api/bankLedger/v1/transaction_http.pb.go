@@ -20,11 +20,13 @@ var _ = binding.EncodeURL
 const _ = http.SupportPackageIsVersion1
 
 const OperationTransactionCreateTransaction = "/bankLedger.v1.Transaction/CreateTransaction"
+const OperationTransactionGetStatement = "/bankLedger.v1.Transaction/GetStatement"
 const OperationTransactionGetTransactionById = "/bankLedger.v1.Transaction/GetTransactionById"
 const OperationTransactionGetTransactionsByAccount = "/bankLedger.v1.Transaction/GetTransactionsByAccount"
 
 type TransactionHTTPServer interface {
 	CreateTransaction(context.Context, *CreateTransactionRequest) (*CreateTransactionResponse, error)
+	GetStatement(context.Context, *GetStatementRequest) (*GetStatementResponse, error)
 	GetTransactionById(context.Context, *GetTransactionByIdRequest) (*GetTransactionResponse, error)
 	GetTransactionsByAccount(context.Context, *GetTransactionsByAccountRequest) (*GetTransactionsByAccountResponse, error)
 }
@@ -34,6 +36,7 @@ func RegisterTransactionHTTPServer(s *http.Server, srv TransactionHTTPServer) {
 	r.POST("/v1/transaction", _Transaction_CreateTransaction0_HTTP_Handler(srv))
 	r.GET("/v1/transaction/{transaction_id}", _Transaction_GetTransactionById0_HTTP_Handler(srv))
 	r.GET("/v1/account/{account_id}/transactions", _Transaction_GetTransactionsByAccount0_HTTP_Handler(srv))
+	r.GET("/v1/account/{account_id}/statement", _Transaction_GetStatement0_HTTP_Handler(srv))
 }
 
 func _Transaction_CreateTransaction0_HTTP_Handler(srv TransactionHTTPServer) func(ctx http.Context) error {
@@ -102,8 +105,31 @@ func _Transaction_GetTransactionsByAccount0_HTTP_Handler(srv TransactionHTTPServ
 	}
 }
 
+func _Transaction_GetStatement0_HTTP_Handler(srv TransactionHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetStatementRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationTransactionGetStatement)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetStatement(ctx, req.(*GetStatementRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetStatementResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 type TransactionHTTPClient interface {
 	CreateTransaction(ctx context.Context, req *CreateTransactionRequest, opts ...http.CallOption) (rsp *CreateTransactionResponse, err error)
+	GetStatement(ctx context.Context, req *GetStatementRequest, opts ...http.CallOption) (rsp *GetStatementResponse, err error)
 	GetTransactionById(ctx context.Context, req *GetTransactionByIdRequest, opts ...http.CallOption) (rsp *GetTransactionResponse, err error)
 	GetTransactionsByAccount(ctx context.Context, req *GetTransactionsByAccountRequest, opts ...http.CallOption) (rsp *GetTransactionsByAccountResponse, err error)
 }
@@ -129,6 +155,19 @@ func (c *TransactionHTTPClientImpl) CreateTransaction(ctx context.Context, in *C
 	return &out, nil
 }
 
+func (c *TransactionHTTPClientImpl) GetStatement(ctx context.Context, in *GetStatementRequest, opts ...http.CallOption) (*GetStatementResponse, error) {
+	var out GetStatementResponse
+	pattern := "/v1/account/{account_id}/statement"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationTransactionGetStatement))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *TransactionHTTPClientImpl) GetTransactionById(ctx context.Context, in *GetTransactionByIdRequest, opts ...http.CallOption) (*GetTransactionResponse, error) {
 	var out GetTransactionResponse
 	pattern := "/v1/transaction/{transaction_id}"
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+
+	"github.com/go-kratos/kratos/v2/transport/http"
+)
+
+var (
+	// addr is the bank ledger http endpoint.
+	addr string
+	// file is the batch file to upload.
+	file string
+	// format is the batch file format, detected from the extension when empty.
+	format string
+	// name is the batch name, defaults to the file name.
+	name string
+	// watch polls the batch until every line has settled.
+	watch bool
+	// interval is the polling interval used with -watch.
+	interval time.Duration
+)
+
+func init() {
+	flag.StringVar(&addr, "addr", "http://localhost:8001", "bank ledger http endpoint")
+	flag.StringVar(&file, "file", "", "batch file to upload, eg: -file payroll.xml")
+	flag.StringVar(&format, "format", "", "batch file format: pain001 or csv")
+	flag.StringVar(&name, "name", "", "batch name, defaults to the file name")
+	flag.BoolVar(&watch, "watch", false, "poll batch progress until it completes")
+	flag.DurationVar(&interval, "interval", 2*time.Second, "polling interval used with -watch")
+}
+
+func main() {
+	flag.Parse()
+	if err := run(context.Background()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context) error {
+	if file == "" {
+		return fmt.Errorf("-file is required")
+	}
+
+	batchFormat, err := detectFormat(format, file)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	if name == "" {
+		name = filepath.Base(file)
+	}
+
+	conn, err := http.NewClient(ctx, http.WithEndpoint(addr))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := v1.NewBatchHTTPClient(conn)
+
+	batch, err := client.CreateBatch(ctx, &v1.CreateBatchRequest{
+		Name:    name,
+		Format:  batchFormat,
+		Content: content,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create batch: %w", err)
+	}
+	fmt.Printf("batch %s created with %d lines\n", batch.Id, batch.Progress.GetTotalLines())
+
+	for watch {
+		printProgress(batch)
+		if settled(batch) {
+			break
+		}
+		time.Sleep(interval)
+		batch, err = client.GetBatch(ctx, &v1.BaseRequest{Id: batch.Id})
+		if err != nil {
+			return fmt.Errorf("failed to get batch: %w", err)
+		}
+	}
+
+	for _, line := range batch.Lines {
+		if line.Status == v1.BatchLineStatus_LINE_REJECTED {
+			fmt.Printf("line %d rejected: %s\n", line.LineNumber, line.Error)
+		}
+	}
+	return nil
+}
+
+func detectFormat(format, file string) (v1.BatchFormat, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".xml":
+			format = "pain001"
+		case ".csv":
+			format = "csv"
+		default:
+			return 0, fmt.Errorf("cannot detect format of %s, use -format", file)
+		}
+	}
+
+	switch strings.ToLower(strings.ReplaceAll(format, ".", "")) {
+	case "pain001", "pain_001":
+		return v1.BatchFormat_PAIN_001, nil
+	case "csv":
+		return v1.BatchFormat_CSV, nil
+	default:
+		return 0, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+func printProgress(batch *v1.BatchResponse) {
+	p := batch.Progress
+	fmt.Printf("%s: %d total, %d pending, %d rejected, %d submitted, %d succeeded, %d failed\n",
+		batch.Status, p.GetTotalLines(), p.GetPendingLines(), p.GetRejectedLines(),
+		p.GetSubmittedLines(), p.GetSucceededLines(), p.GetFailedLines())
+}
+
+// settled reports whether every line of a completed batch has reached a final state.
+func settled(batch *v1.BatchResponse) bool {
+	if batch.Status != v1.BatchStatus_BATCH_COMPLETED {
+		return false
+	}
+	p := batch.Progress
+	return p.GetRejectedLines()+p.GetSucceededLines()+p.GetFailedLines() >= p.GetTotalLines()
+}
@@ -2,16 +2,26 @@ package main
 
 import (
 	v1 "bank-ledger/api/bankLedger/v1"
+	"bank-ledger/internal/biz"
 	"bank-ledger/internal/conf"
 	"bank-ledger/internal/data"
 	"bank-ledger/internal/entity"
+	"bank-ledger/internal/kafka"
+	"bank-ledger/internal/metrics"
+	"bank-ledger/internal/propagation"
+	"bank-ledger/internal/server"
+	"bank-ledger/internal/telemetry"
 	"context"
-	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"gorm.io/gorm"
 	"os"
 	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -20,6 +30,10 @@ import (
 	"github.com/go-kratos/kratos/v2/config/file"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware/tracing"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/rs/xid"
+	"go.opentelemetry.io/otel/codes"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 var (
@@ -33,176 +47,569 @@ func init() {
 	flag.StringVar(&flagconf, "conf", "./configs", "config path, eg: -conf config.yaml")
 }
 
-type Transaction struct {
-	TransactionID string  `json:"transaction_id"`
-	AccountID     string  `json:"account_id"`
-	Amount        float64 `json:"amount"`
-	Type          string  `json:"type"`
-	Description   string  `json:"description"`
-	Currency      string  `json:"currency"`
-	Status        string  `json:"status"`
-	CreatedAt     string  `json:"created_at"`
-}
+const (
+	// defaultConsumerGroup is used when conf.Consumer.group is empty.
+	defaultConsumerGroup = "transaction-consumer-group"
+	// defaultMessageTimeout is used when conf.Consumer.message_timeout is unset.
+	defaultMessageTimeout = 10 * time.Second
+	// defaultDrainTimeout is used when conf.Consumer.drain_timeout is unset.
+	defaultDrainTimeout = 30 * time.Second
+	// defaultConcurrency is used when conf.Consumer.concurrency is unset.
+	defaultConcurrency = 1
+)
+
+// errAlreadyProcessed aborts the database transaction of a command the inbox
+// shows as applied, or whose transaction has already failed.
+var errAlreadyProcessed = errors.New("transaction already processed")
 
 type TransactionHandler struct {
-	log      *log.Helper
-	logger   log.Logger
-	confData *conf.Data
+	log                 *log.Helper
+	data                *data.Data
+	accountRepo         data.AccountRepository
+	transactionRepo     data.TransactionRepository
+	transactionLogsRepo data.TransactionLogsRepository
+	outboxRepo          data.OutboxRepository
+	sequenceRepo        data.SequenceRepository
+	inboxRepo           data.InboxRepository
+	fees                biz.FeeEngine
+	producer            kafka.Producer
+	topics              *biz.Topics
+	webhooks            biz.WebhookHandler
+	messageTimeout      time.Duration
+	concurrency         int
 }
 
 func (h *TransactionHandler) Setup(sarama.ConsumerGroupSession) error {
 	return nil
 }
 
-func (h *TransactionHandler) Cleanup(sarama.ConsumerGroupSession) error {
+// Cleanup commits the offsets marked during the session before partitions are
+// released, on rebalance as well as on shutdown.
+func (h *TransactionHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	session.Commit()
 	return nil
 }
 
+// ConsumeClaim handles up to h.concurrency messages of the partition at once.
+// Messages touching the same account run one at a time in offset order, and
+// offsets are marked only up to the lowest message still in flight. Messages
+// already being handled are finished when the session ends; their contexts are
+// independent of the session.
 func (h *TransactionHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	baseCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	var (
+		locks   = newAccountLocks()
+		offsets = newOffsetTracker(session)
+		slots   = make(chan struct{}, h.concurrency)
+		wg      sync.WaitGroup
+	)
+	defer wg.Wait()
 
-	for message := range claim.Messages() {
-		ctx, msgCancel := context.WithTimeout(baseCtx, 10*time.Second)
+	for {
+		select {
+		case message, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
 
-		h.log.Infof("Received message: topic=%s partition=%d offset=%d", message.Topic, message.Partition, message.Offset)
+			// Locks are taken in offset order, which keeps each account's
+			// messages in the order they were produced.
+			keys := accountKeys(message)
+			tracked := offsets.Start(message)
+			if !locks.Lock(session.Context(), keys) {
+				return nil
+			}
+			select {
+			case slots <- struct{}{}:
+			case <-session.Context().Done():
+				locks.Unlock(keys)
+				return nil
+			}
 
-		var transaction Transaction
-		if err := json.Unmarshal(message.Value, &transaction); err != nil {
-			h.log.Errorf("Failed to unmarshal transaction: %v", err)
-			msgCancel()
-			continue
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				h.handleMessage(claim, message)
+				locks.Unlock(keys)
+				<-slots
+				offsets.Done(tracked)
+			}()
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+// accountKeys returns the accounts a message touches. Messages that cannot be
+// decoded are keyed by their record key and dead-lettered by handleMessage.
+func accountKeys(message *sarama.ConsumerMessage) []string {
+	transaction, err := biz.DecodeTransactionCommand(message.Value, recordHeaders(message))
+	if err != nil {
+		return []string{"record:" + string(message.Key)}
+	}
+	keys := []string{transaction.AccountId}
+	if transaction.CounterpartyAccountId != "" {
+		keys = append(keys, transaction.CounterpartyAccountId)
+	}
+	return keys
+}
+
+// handleMessage applies one transaction command. Every outcome completes the
+// message, including failed attempts, whose state is recorded for retry.
+func (h *TransactionHandler) handleMessage(claim sarama.ConsumerGroupClaim, message *sarama.ConsumerMessage) {
+	start := time.Now()
+	headers := recordHeaders(message)
+	ctx, msgCancel := context.WithTimeout(kafka.ContextFromHeaders(context.Background(), headers), h.messageTimeout)
+	ctx, span := kafka.StartConsumerSpan(ctx, message.Topic, message.Partition, message.Offset)
+	logger := h.log.WithContext(ctx)
+	finish := func(result string) {
+		observe(claim, message, start, result)
+		if result != metrics.ResultSucceeded {
+			span.SetStatus(codes.Error, result)
 		}
+		span.End()
+		msgCancel()
+	}
+
+	logger.Infof("Received message: topic=%s partition=%d offset=%d", message.Topic, message.Partition, message.Offset)
+
+	transaction, err := biz.DecodeTransactionCommand(message.Value, headers)
+	if err != nil {
+		// Park commands this build cannot read, e.g. from a newer producer,
+		// so they can be replayed after an upgrade instead of blocking the partition.
+		logger.Errorf("Failed to decode transaction command: %v", err)
+		h.deadLetter(message, err)
+		finish(metrics.ResultDeadLettered)
+		return
+	}
 
-		dataData, cleanup, err := data.NewData(h.confData, h.logger)
+	entityTransaction := &entity.Transaction{
+		ID:                    transaction.TransactionId,
+		AccountID:             transaction.AccountId,
+		CounterpartyAccountID: transaction.CounterpartyAccountId,
+		Amount:                transaction.Amount,
+		Currency:              transaction.Currency,
+		Description:           transaction.Description,
+		Type:                  transaction.Type.String(),
+		ProcessDescription:    "Transaction under process",
+		Status:                v1.TransactionStatus_PROCESSING.String(),
+		CreatedAt:             parseTimestamp(transaction.CreatedAt),
+		Sequence:              transaction.Sequence,
+	}
+
+	// Status events are published once processing of this attempt is over so
+	// watchers never observe a SUCCESS that is later rolled back.
+	var events []*v1.TransactionEvent
+	err = h.data.DB().Transaction(func(tx *gorm.DB) error {
+		accounts := h.accountRepo.WithTx(tx)
+		transactions := h.transactionRepo.WithTx(tx)
+		inbox := h.inboxRepo.WithTx(tx)
+
+		processed, err := inbox.Processed(ctx, transaction.TransactionId)
 		if err != nil {
-			h.log.Errorf("Failed to create data: %v", err)
-			msgCancel()
-			continue
+			return fmt.Errorf("failed to check inbox: %w", err)
+		}
+		if processed {
+			return errAlreadyProcessed
 		}
 
-		mongoData, cleanup, err := data.NewMongoDBConnection(h.confData, h.logger)
+		existingTx, err := transactions.FindByID(ctx, &v1.BaseRequest{Id: transaction.TransactionId})
+		if err == nil {
+			// A transaction failed meanwhile, e.g. by the stuck-transaction
+			// sweeper, must not be applied by a late command.
+			if existingTx.Status == v1.TransactionStatus_FAILED.String() {
+				return errAlreadyProcessed
+			}
+			entityTransaction.RetryCount = existingTx.RetryCount + 1
+			entityTransaction.SweepAttempts = existingTx.SweepAttempts
+		} else {
+			entityTransaction.RetryCount = 1
+		}
+
+		if err := transactions.Update(ctx, entityTransaction); err != nil {
+			return fmt.Errorf("failed to upsert transaction: %w", err)
+		}
+
+		if err := h.appendLog(ctx, h.transactionLogsRepo, entityTransaction, &events, entity.LogEntry{
+			Timestamp: time.Now(),
+			Message:   "Transaction processing started",
+			Status:    v1.TransactionStatus_PROCESSING.String(),
+		}); err != nil {
+			return fmt.Errorf("failed to append transaction log: %w", err)
+		}
+
+		if err := h.checkSequence(ctx, h.sequenceRepo.WithTx(tx), entityTransaction); err != nil {
+			return fmt.Errorf("failed to check account sequence: %w", err)
+		}
+
+		account, counterparty, err := lockAccounts(ctx, accounts, transaction.AccountId, transaction.CounterpartyAccountId)
 		if err != nil {
-			h.log.Errorf("Failed to create MongoDB connection: %v", err)
-			msgCancel()
-			continue
+			return err
+		}
+		if account.Status == v1.AccountStatus_FROZEN.String() {
+			return fmt.Errorf("account is frozen: %s", transaction.AccountId)
+		}
+		previousBalance := account.Balance
+		var counterpartyPrevious float64
+
+		// Commands are validated by the API; a non-positive amount would turn
+		// a debit into a credit and is never applied.
+		if transaction.Amount <= 0 {
+			return fmt.Errorf("amount must be positive: %v", transaction.Amount)
+		}
+
+		feeLegs := h.fees.Evaluate(transaction.Type.String(), transaction.Amount)
+		var totalFee float64
+		for _, leg := range feeLegs {
+			totalFee += leg.Amount
 		}
-		defer cleanup()
-		accountRepo := data.NewAccountRepo(dataData, h.logger)
-		transactionRepo := data.NewTransactionRepo(dataData, h.logger)
-		transactionLogsRepo := data.NewTransactionLogsRepo(dataData, h.logger, mongoData)
-
-		entityTransaction := &entity.Transaction{
-			ID:                 transaction.TransactionID,
-			AccountID:          transaction.AccountID,
-			Amount:             transaction.Amount,
-			Currency:           transaction.Currency,
-			Description:        transaction.Description,
-			Type:               transaction.Type,
-			ProcessDescription: "Transaction under process",
-			Status:             v1.TransactionStatus_PROCESSING.String(),
-			CreatedAt:          parseTimestamp(transaction.CreatedAt),
-		}
-
-		err = dataData.DB().Transaction(func(tx *gorm.DB) error {
-			ctxTx := context.WithValue(ctx, "tx", tx)
-
-			existingTx, err := transactionRepo.FindByID(ctxTx, &v1.BaseRequest{Id: transaction.TransactionID})
-			if err == nil {
-				entityTransaction.RetryCount = existingTx.RetryCount + 1
-			} else {
-				entityTransaction.RetryCount = 1
+
+		switch transaction.Type {
+		case v1.TransactionType_DEPOSIT:
+			account.Balance += transaction.Amount
+
+		case v1.TransactionType_WITHDRAWAL:
+			if account.Balance < transaction.Amount+totalFee {
+				return fmt.Errorf("insufficient balance for account: %s", transaction.AccountId)
 			}
+			account.Balance -= transaction.Amount
 
-			if err := transactionRepo.Update(ctxTx, entityTransaction); err != nil {
-				return fmt.Errorf("failed to upsert transaction: %w", err)
+		case v1.TransactionType_TRANSFER:
+			if account.Balance < transaction.Amount+totalFee {
+				return fmt.Errorf("insufficient balance for account: %s", transaction.AccountId)
 			}
 
-			if err := transactionLogsRepo.AppendTransactionLog(ctxTx, transaction.TransactionID, entityTransaction.RetryCount, entity.LogEntry{
-				Timestamp: time.Now(),
-				Message:   "Transaction processing started",
-				Status:    v1.TransactionStatus_PROCESSING.String(),
-			}); err != nil {
-				return fmt.Errorf("failed to append transaction log: %w", err)
+			if counterparty == nil {
+				return fmt.Errorf("counterparty account is required for transfers")
+			}
+			if counterparty.Status == v1.AccountStatus_CLOSED.String() {
+				return fmt.Errorf("counterparty account is closed: %s", transaction.CounterpartyAccountId)
+			}
+			if counterparty.Status == v1.AccountStatus_FROZEN.String() {
+				return fmt.Errorf("counterparty account is frozen: %s", transaction.CounterpartyAccountId)
 			}
 
-			account, err := accountRepo.FindByID(ctxTx, &v1.BaseRequest{Id: transaction.AccountID})
-			if err != nil {
-				return fmt.Errorf("account not found: %w", err)
+			counterpartyPrevious = counterparty.Balance
+			account.Balance -= transaction.Amount
+			counterparty.Balance += transaction.Amount
+			if err := accounts.Update(ctx, counterparty); err != nil {
+				return fmt.Errorf("failed to update counterparty account: %w", err)
 			}
 
-			switch transaction.Type {
-			case v1.TransactionType_DEPOSIT.String():
-				account.Balance += transaction.Amount
+			if err := transactions.Create(ctx, transferCreditLeg(entityTransaction)); err != nil {
+				return fmt.Errorf("failed to record transfer credit: %w", err)
+			}
 
-			case v1.TransactionType_WITHDRAWAL.String():
-				if account.Balance < transaction.Amount {
-					return fmt.Errorf("insufficient balance for account: %s", transaction.AccountID)
-				}
-				account.Balance -= transaction.Amount
+		default:
+			return fmt.Errorf("unknown transaction type: %s", transaction.Type)
+		}
 
-			default:
-				return fmt.Errorf("unknown transaction type: %s", transaction.Type)
-			}
+		if account.Balance < totalFee {
+			return fmt.Errorf("insufficient balance for fees on account: %s", transaction.AccountId)
+		}
+		account.Balance -= totalFee
 
-			if err := accountRepo.Update(ctxTx, account); err != nil {
-				return fmt.Errorf("failed to update account: %w", err)
-			}
+		if err := accounts.Update(ctx, account); err != nil {
+			return fmt.Errorf("failed to update account: %w", err)
+		}
 
-			entityTransaction.Status = v1.TransactionStatus_SUCCESS.String()
-			entityTransaction.ProcessDescription = "Transaction processed successfully"
-			if err := transactionRepo.Update(ctxTx, entityTransaction); err != nil {
-				return fmt.Errorf("failed to update transaction to SUCCESS: %w", err)
+		feeTxs := h.fees.FeeTransactions(entityTransaction, feeLegs)
+		for _, feeTx := range feeTxs {
+			if err := transactions.Create(ctx, feeTx); err != nil {
+				return fmt.Errorf("failed to record fee %s: %w", feeTx.FeeRule, err)
 			}
 
-			if err := transactionLogsRepo.AppendTransactionLog(ctxTx, transaction.TransactionID, entityTransaction.RetryCount, entity.LogEntry{
+			if err := h.appendLog(ctx, h.transactionLogsRepo, entityTransaction, &events, entity.LogEntry{
 				Timestamp: time.Now(),
-				Message:   "Transaction processed successfully",
-				Status:    v1.TransactionStatus_SUCCESS.String(),
+				Message:   fmt.Sprintf("Fee %s of %.2f charged as transaction %s", feeTx.FeeRule, feeTx.Amount, feeTx.ID),
+				Status:    v1.TransactionStatus_PROCESSING.String(),
 			}); err != nil {
 				return fmt.Errorf("failed to append transaction log: %w", err)
-			} else {
-				h.log.Infof("Appended log for transaction %s (try #%d)", transaction.TransactionID, entityTransaction.RetryCount)
 			}
+		}
 
-			return nil
-		})
+		processedAt := time.Now()
+		entityTransaction.Status = v1.TransactionStatus_SUCCESS.String()
+		entityTransaction.ProcessDescription = "Transaction processed successfully"
+		entityTransaction.ProcessedAt = &processedAt
+		if err := transactions.Update(ctx, entityTransaction); err != nil {
+			return fmt.Errorf("failed to update transaction to SUCCESS: %w", err)
+		}
 
-		if err != nil {
-			h.log.Errorf("Transaction processing failed: %v", err)
-
-			if entityTransaction.RetryCount >= 5 {
-				entityTransaction.Status = v1.TransactionStatus_FAILED.String()
-				entityTransaction.ProcessDescription = err.Error()
-			} else {
-				entityTransaction.Status = v1.TransactionStatus_PROCESSING.String()
-				entityTransaction.ProcessDescription = "Retrying due to error: " + err.Error()
+		ledgerEvents := []*v1.LedgerEvent{
+			biz.TransactionSucceededEvent(entityTransaction, feeTxs),
+			biz.BalanceChangedEvent(account, previousBalance, entityTransaction.ID),
+		}
+		if counterparty != nil {
+			ledgerEvents = append(ledgerEvents, biz.BalanceChangedEvent(counterparty, counterpartyPrevious, entityTransaction.ID))
+		}
+		if err := biz.WriteEvents(ctx, h.outboxRepo.WithTx(tx), ledgerEvents...); err != nil {
+			return fmt.Errorf("failed to record ledger events: %w", err)
+		}
+
+		if err := inbox.Create(ctx, &entity.ProcessedMessage{
+			TransactionID: transaction.TransactionId,
+			Topic:         message.Topic,
+			Partition:     message.Partition,
+			Offset:        message.Offset,
+			ProcessedAt:   time.Now(),
+		}); err != nil {
+			return fmt.Errorf("failed to record processed message: %w", err)
+		}
+
+		if err := h.appendLog(ctx, h.transactionLogsRepo, entityTransaction, &events, entity.LogEntry{
+			Timestamp: time.Now(),
+			Message:   "Transaction processed successfully",
+			Status:    v1.TransactionStatus_SUCCESS.String(),
+		}); err != nil {
+			return fmt.Errorf("failed to append transaction log: %w", err)
+		} else {
+			logger.Infof("Appended log for transaction %s (try #%d)", transaction.TransactionId, entityTransaction.RetryCount)
+		}
+
+		return nil
+	})
+
+	// A redelivered command, or one committed meanwhile by another consumer
+	// after a rebalance, has already been settled and is skipped.
+	if errors.Is(err, errAlreadyProcessed) || err != nil && h.processed(ctx, transaction.TransactionId) {
+		logger.Infof("Transaction %s was already settled, skipping redelivery", transaction.TransactionId)
+		finish(metrics.ResultDuplicate)
+		return
+	}
+
+	if err != nil {
+		logger.Errorf("Transaction processing failed: %v", err)
+
+		if entityTransaction.RetryCount >= 5 {
+			entityTransaction.Status = v1.TransactionStatus_FAILED.String()
+			entityTransaction.ProcessDescription = err.Error()
+		} else {
+			entityTransaction.Status = v1.TransactionStatus_PROCESSING.String()
+			entityTransaction.ProcessDescription = "Retrying due to error: " + err.Error()
+		}
+
+		if updateErr := h.transactionRepo.Update(ctx, entityTransaction); updateErr != nil {
+			logger.Errorf("Failed to update transaction status: %v", updateErr)
+		}
+
+		if entityTransaction.Status == v1.TransactionStatus_FAILED.String() {
+			if eventErr := biz.WriteEvents(ctx, h.outboxRepo, biz.TransactionFailedEvent(entityTransaction, err.Error())); eventErr != nil {
+				logger.Errorf("Failed to record ledger event: %v", eventErr)
 			}
+		}
 
-			if updateErr := transactionRepo.Update(ctx, entityTransaction); updateErr != nil {
-				h.log.Errorf("Failed to update transaction status: %v", updateErr)
+		// A SUCCESS queued before the commit failed never took effect.
+		attempted := events[:0]
+		for _, event := range events {
+			if event.Status != v1.TransactionStatus_SUCCESS {
+				attempted = append(attempted, event)
 			}
+		}
+		events = attempted
+
+		if logErr := h.appendLog(ctx, h.transactionLogsRepo, entityTransaction, &events, entity.LogEntry{
+			Timestamp: time.Now(),
+			Message:   entityTransaction.ProcessDescription,
+			Status:    entityTransaction.Status,
+		}); logErr != nil {
+			logger.Errorf("Failed to append transaction log: %v", logErr)
+		}
 
-			if logErr := transactionLogsRepo.AppendTransactionLog(ctx, transaction.TransactionID, entityTransaction.RetryCount, entity.LogEntry{
-				Timestamp: time.Now(),
-				Message:   entityTransaction.ProcessDescription,
-				Status:    entityTransaction.Status,
-			}); logErr != nil {
-				h.log.Errorf("Failed to append transaction log: %v", logErr)
+		h.publishEvents(ctx, events)
+		h.notifyTransition(ctx, entityTransaction)
+		if entityTransaction.Status == v1.TransactionStatus_FAILED.String() {
+			finish(metrics.ResultFailed)
+		} else {
+			finish(metrics.ResultError)
+		}
+		return
+	}
+
+	logger.Infof("Transaction %s processed successfully", transaction.TransactionId)
+	h.publishEvents(ctx, events)
+	h.notifyTransition(ctx, entityTransaction)
+	finish(metrics.ResultSucceeded)
+}
+
+// processed reports whether the inbox records the transaction as applied.
+// Lookup errors count as not processed so the failure is handled normally.
+func (h *TransactionHandler) processed(ctx context.Context, transactionID string) bool {
+	processed, err := h.inboxRepo.Processed(ctx, transactionID)
+	if err != nil {
+		h.log.WithContext(ctx).Errorf("Failed to check inbox for transaction %s: %v", transactionID, err)
+		return false
+	}
+	return processed
+}
+
+// lockAccounts reads the account and, when set, the counterparty with row
+// locks held until the transaction ends. Rows are locked in id order so two
+// transfers between the same accounts cannot deadlock.
+func lockAccounts(ctx context.Context, accounts data.AccountRepository, accountID, counterpartyID string) (*entity.Account, *entity.Account, error) {
+	ids := []string{accountID}
+	if counterpartyID != "" && counterpartyID != accountID {
+		ids = append(ids, counterpartyID)
+		sort.Strings(ids)
+	}
+
+	locked := make(map[string]*entity.Account, len(ids))
+	for _, id := range ids {
+		acc, err := accounts.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			if id == accountID {
+				return nil, nil, fmt.Errorf("account not found: %w", err)
 			}
+			return nil, nil, fmt.Errorf("counterparty account not found: %w", err)
+		}
+		locked[id] = acc
+	}
+	if counterpartyID == "" {
+		return locked[accountID], nil, nil
+	}
+	return locked[accountID], locked[counterpartyID], nil
+}
+
+// checkSequence advances the account's applied sequence and reports commands
+// that arrive out of order, twice, or after a gap. Anomalies are logged and
+// counted but do not stop processing: the balance checks still guard the
+// account, and gaps are expected when the API fails after issuing a number.
+func (h *TransactionHandler) checkSequence(ctx context.Context, seqs data.SequenceRepository, trx *entity.Transaction) error {
+	if trx.Sequence == 0 {
+		return nil
+	}
+	prev, err := seqs.Advance(ctx, trx.AccountID, trx.Sequence)
+	if err != nil {
+		return err
+	}
+
+	var kind string
+	switch {
+	case trx.Sequence < prev:
+		kind = metrics.SequenceOutOfOrder
+	case trx.Sequence == prev:
+		kind = metrics.SequenceDuplicate
+	case prev > 0 && trx.Sequence > prev+1:
+		kind = metrics.SequenceGap
+	default:
+		return nil
+	}
+
+	metrics.ConsumerSequenceAnomalies.WithLabelValues(kind).Inc()
+	message := fmt.Sprintf("Sequence %d for account %s is %s; last applied was %d", trx.Sequence, trx.AccountID, strings.ReplaceAll(kind, "_", " "), prev)
+	h.log.WithContext(ctx).Warn(message)
+	if err := h.transactionLogsRepo.AppendTransactionLog(ctx, trx.ID, trx.RetryCount, biz.StampLogEntry(ctx, entity.LogEntry{
+		Timestamp: time.Now(),
+		Message:   message,
+		Status:    v1.TransactionStatus_PROCESSING.String(),
+	})); err != nil {
+		h.log.WithContext(ctx).Errorf("Failed to append transaction log: %v", err)
+	}
+	return nil
+}
+
+// observe records the outcome and latency of a handled message and the
+// partition's remaining lag.
+func observe(claim sarama.ConsumerGroupClaim, message *sarama.ConsumerMessage, start time.Time, result string) {
+	metrics.ConsumerMessages.WithLabelValues(message.Topic, result).Inc()
+	metrics.ConsumerProcessingSeconds.WithLabelValues(message.Topic, result).Observe(time.Since(start).Seconds())
+
+	lag := claim.HighWaterMarkOffset() - message.Offset - 1
+	if lag < 0 {
+		lag = 0
+	}
+	metrics.ConsumerLag.WithLabelValues(message.Topic, strconv.Itoa(int(message.Partition))).Set(float64(lag))
+}
+
+// deadLetter forwards an undecodable command, with its original headers and
+// the reason, to the dead-letter topic.
+func (h *TransactionHandler) deadLetter(message *sarama.ConsumerMessage, reason error) {
+	headers := make([]kafka.Header, 0, len(message.Headers)+1)
+	for _, header := range message.Headers {
+		headers = append(headers, kafka.Header{Key: string(header.Key), Value: string(header.Value)})
+	}
+	headers = append(headers, kafka.Header{Key: "dlq-reason", Value: reason.Error()})
+
+	if err := h.producer.SendMessage(h.topics.TransactionsDeadLetter, message.Key, message.Value, headers...); err != nil {
+		h.log.Errorf("Failed to dead-letter message at partition %d offset %d: %v", message.Partition, message.Offset, err)
+	}
+}
+
+func recordHeaders(message *sarama.ConsumerMessage) map[string]string {
+	headers := make(map[string]string, len(message.Headers))
+	for _, header := range message.Headers {
+		headers[string(header.Key)] = string(header.Value)
+	}
+	return headers
+}
 
-			msgCancel()
+// appendLog records a log entry for the transaction and queues the matching
+// status event for publishing.
+func (h *TransactionHandler) appendLog(ctx context.Context, logs data.TransactionLogsRepository, trx *entity.Transaction, events *[]*v1.TransactionEvent, entry entity.LogEntry) error {
+	entry = biz.StampLogEntry(ctx, entry)
+	if err := logs.AppendTransactionLog(ctx, trx.ID, trx.RetryCount, entry); err != nil {
+		return err
+	}
+	*events = append(*events, biz.NewTransactionEvent(trx, trx.RetryCount, entry))
+	return nil
+}
+
+// publishEvents sends queued status events to watchers. Failures are logged
+// only; the recorded log in MongoDB stays the source of truth.
+func (h *TransactionHandler) publishEvents(ctx context.Context, events []*v1.TransactionEvent) {
+	headers := kafka.ContextHeaders(ctx)
+	for _, event := range events {
+		payload, err := protojson.Marshal(event)
+		if err != nil {
+			h.log.Errorf("Failed to marshal transaction event: %v", err)
 			continue
 		}
+		if err := h.producer.SendMessage(h.topics.TransactionStatus, []byte(event.TransactionId), payload, headers...); err != nil {
+			h.log.Errorf("Failed to publish transaction event: %v", err)
+		}
+	}
+}
 
-		h.log.Infof("Transaction %s processed successfully", transaction.TransactionID)
+// notifyTransition queues webhooks for the status transitions made by this
+// attempt: entering PROCESSING on the first attempt, then SUCCESS or FAILED.
+func (h *TransactionHandler) notifyTransition(ctx context.Context, trx *entity.Transaction) {
+	if trx.RetryCount == 1 {
+		processing := biz.ToProtoTransaction(trx)
+		processing.Status = v1.TransactionStatus_PROCESSING
+		h.notify(ctx, biz.WebhookEventTransactionProcessing, processing)
+	}
+	switch trx.Status {
+	case v1.TransactionStatus_SUCCESS.String():
+		h.notify(ctx, biz.WebhookEventTransactionSucceeded, biz.ToProtoTransaction(trx))
+	case v1.TransactionStatus_FAILED.String():
+		h.notify(ctx, biz.WebhookEventTransactionFailed, biz.ToProtoTransaction(trx))
+	}
+}
 
-		// Mark message as processed
-		session.MarkMessage(message, "")
-		msgCancel()
+func (h *TransactionHandler) notify(ctx context.Context, eventType string, trx *v1.EachTransaction) {
+	if err := h.webhooks.Enqueue(ctx, eventType, trx, trx.AccountId, trx.CounterpartyAccountId); err != nil {
+		h.log.Errorf("Failed to enqueue %s webhook for transaction %s: %v", eventType, trx.Id, err)
 	}
+}
 
-	return nil
+// transferCreditLeg records the incoming side of a transfer on the counterparty
+// account as a deposit linked to the originating transfer.
+func transferCreditLeg(transfer *entity.Transaction) *entity.Transaction {
+	now := time.Now()
+	return &entity.Transaction{
+		ID:                    xid.New().String(),
+		AccountID:             transfer.CounterpartyAccountID,
+		CounterpartyAccountID: transfer.AccountID,
+		Amount:                transfer.Amount,
+		Currency:              transfer.Currency,
+		Type:                  v1.TransactionType_DEPOSIT.String(),
+		Status:                v1.TransactionStatus_SUCCESS.String(),
+		Description:           fmt.Sprintf("Transfer from account %s", transfer.AccountID),
+		ProcessDescription:    "Transfer credited",
+		ParentTransactionID:   transfer.ID,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+		ProcessedAt:           &now,
+	}
 }
 
 func parseTimestamp(timestamp string) time.Time {
@@ -213,6 +620,18 @@ func parseTimestamp(timestamp string) time.Time {
 	return parsedTime
 }
 
+// parseInitialOffset maps the configured initial offset to sarama's. Oldest is
+// the default so messages published before the group's first start are kept.
+func parseInitialOffset(offset string) (int64, error) {
+	switch strings.ToLower(offset) {
+	case "", "oldest":
+		return sarama.OffsetOldest, nil
+	case "newest":
+		return sarama.OffsetNewest, nil
+	}
+	return 0, fmt.Errorf("initial_offset must be oldest or newest, got %q", offset)
+}
+
 func main() {
 	flag.Parse()
 
@@ -225,6 +644,8 @@ func main() {
 		"service.version", Version,
 		"trace.id", tracing.TraceID(),
 		"span.id", tracing.SpanID(),
+		"request.id", propagation.RequestID(),
+		"correlation.id", propagation.CorrelationID(),
 	)
 
 	logHelper := log.NewHelper(logger)
@@ -242,19 +663,64 @@ func main() {
 		panic(err)
 	}
 
+	shutdownTracing, err := telemetry.NewTracerProvider(bc.Tracing, Name, Version, id)
+	if err != nil {
+		logHelper.Errorf("Failed to set up tracing: %v", err)
+		return
+	}
+	defer shutdownTracing()
+
 	brokers := bc.Data.Kafka.Brokers
-	consumerGroup := "transaction-consumer-group"
-	topic := "transactions"
+	topics := biz.NewTopics(bc.Data)
+	topic := topics.Transactions
+
+	consumerGroup := defaultConsumerGroup
+	if bc.Consumer.GetGroup() != "" {
+		consumerGroup = bc.Consumer.GetGroup()
+	}
+
+	initialOffset, err := parseInitialOffset(bc.Consumer.GetInitialOffset())
+	if err != nil {
+		logHelper.Errorf("Invalid consumer config: %v", err)
+		return
+	}
 
 	config := sarama.NewConfig()
 	config.Version = sarama.V2_6_0_0
 	config.Consumer.Return.Errors = true
-	config.Consumer.Offsets.Initial = sarama.OffsetNewest
+	config.Consumer.Offsets.Initial = initialOffset
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	client, err := sarama.NewConsumerGroup(brokers, consumerGroup, config)
+	dataData, cleanup, err := data.NewData(bc.Data, logger)
+	if err != nil {
+		logHelper.Errorf("Failed to create data: %v", err)
+		return
+	}
+	defer cleanup()
+
+	fees := biz.NewFeeEngine(bc.Fee, dataData, data.NewAccountRepo(dataData, logger), data.NewTransactionRepo(dataData, logger), data.NewOutboxRepo(dataData, logger), logger)
+
+	producer, err := kafka.NewProducer(bc.Data, logger)
+	if err != nil {
+		logHelper.Errorf("Failed to create producer: %v", err)
+		return
+	}
+	defer producer.Close()
+
+	kafkaClient, err := sarama.NewClient(brokers, config)
+	if err != nil {
+		logHelper.Errorf("Error creating kafka client: %v", err)
+		return
+	}
+	defer func() {
+		if err := kafkaClient.Close(); err != nil && err != sarama.ErrClosedClient {
+			logHelper.Errorf("Error closing kafka client: %v", err)
+		}
+	}()
+
+	client, err := sarama.NewConsumerGroupFromClient(consumerGroup, kafkaClient)
 	if err != nil {
 		logHelper.Errorf("Error creating consumer group: %v", err)
 		return
@@ -265,39 +731,143 @@ func main() {
 		}
 	}()
 
+	mongoData, cleanupMongo, err := data.NewMongoDBConnection(bc.Data, logger)
+	if err != nil {
+		logHelper.Errorf("Failed to create MongoDB connection: %v", err)
+		return
+	}
+	defer cleanupMongo()
+
+	health := server.NewHealth(
+		server.HealthCheck{Name: "kafka", Check: func(ctx context.Context) error {
+			return kafka.Ping(ctx, kafkaClient, topic)
+		}},
+		server.HealthCheck{Name: "mysql", Check: dataData.Ping},
+		server.HealthCheck{Name: "mongodb", Check: func(ctx context.Context) error {
+			return data.PingMongo(ctx, mongoData)
+		}},
+	)
+	hs := server.NewConsumerHTTPServer(bc.Consumer, health, logger)
+	gs := server.NewConsumerGRPCServer(bc.Consumer, health, logger)
+	for _, srv := range []transport.Server{hs, gs} {
+		srv := srv
+		go func() {
+			if err := srv.Start(ctx); err != nil {
+				logHelper.Errorf("Failed to start consumer server: %v", err)
+			}
+		}()
+		defer func() {
+			if err := srv.Stop(context.Background()); err != nil {
+				logHelper.Errorf("Failed to stop consumer server: %v", err)
+			}
+		}()
+	}
+
 	go func() {
 		for err := range client.Errors() {
 			logHelper.Errorf("Consumer group error: %v", err)
 		}
 	}()
 
+	messageTimeout := defaultMessageTimeout
+	if bc.Consumer.GetMessageTimeout() != nil {
+		messageTimeout = bc.Consumer.MessageTimeout.AsDuration()
+	}
+
+	concurrency := defaultConcurrency
+	if bc.Consumer.GetConcurrency() > 0 {
+		concurrency = int(bc.Consumer.Concurrency)
+	}
+
 	handler := &TransactionHandler{
-		log:      logHelper,
-		logger:   logger,
-		confData: bc.Data,
+		log:                 logHelper,
+		data:                dataData,
+		accountRepo:         data.NewAccountRepo(dataData, logger),
+		transactionRepo:     data.NewTransactionRepo(dataData, logger),
+		transactionLogsRepo: data.NewTransactionLogsRepo(dataData, logger, mongoData),
+		outboxRepo:          data.NewOutboxRepo(dataData, logger),
+		sequenceRepo:        data.NewSequenceRepo(dataData, logger),
+		inboxRepo:           data.NewInboxRepo(dataData, logger),
+		fees:                fees,
+		producer:            producer,
+		topics:              topics,
+		webhooks:            biz.NewWebhookHandler(bc.Server, data.NewWebhookRepo(dataData, logger), logger),
+		messageTimeout:      messageTimeout,
+		concurrency:         concurrency,
 	}
 
+	feeInterval := time.Hour
+	if bc.Fee != nil && bc.Fee.Interval != nil {
+		feeInterval = bc.Fee.Interval.AsDuration()
+	}
+
+	// running tracks the goroutines shutdown waits for: the fee ticker and the
+	// consume loop, which returns once in-flight messages are handled.
+	var running sync.WaitGroup
+
+	running.Add(1)
 	go func() {
+		defer running.Done()
+		ticker := time.NewTicker(feeInterval)
+		defer ticker.Stop()
 		for {
+			if err := fees.ChargePeriodicFees(ctx, time.Now()); err != nil {
+				logHelper.Errorf("Failed to charge periodic fees: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	running.Add(1)
+	go func() {
+		defer running.Done()
+		for {
+			// Consume returns on every rebalance and once ctx is cancelled.
 			if err := client.Consume(ctx, []string{topic}, handler); err != nil {
 				logHelper.Errorf("Error consuming: %v", err)
 
-				if ctx.Err() != nil {
-					return
+				select {
+				case <-ctx.Done():
+				case <-time.After(5 * time.Second):
 				}
-
-				time.Sleep(5 * time.Second)
+			}
+			if ctx.Err() != nil {
+				return
 			}
 		}
 	}()
 
-	logHelper.Infof("Transaction consumer started, listening on topic: %s", topic)
+	logHelper.Infof("Transaction consumer started, group: %s, listening on topic: %s", consumerGroup, topic)
 
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
 	<-sig
 	logHelper.Info("Shutting down consumer...")
+
+	// Stop fetching and let in-flight work finish. The session commits marked
+	// offsets as it ends; the deferred closes then release Kafka, MongoDB and
+	// MySQL in reverse order of creation.
 	cancel()
 
-	time.Sleep(2 * time.Second)
+	drainTimeout := defaultDrainTimeout
+	if bc.Consumer.GetDrainTimeout() != nil {
+		drainTimeout = bc.Consumer.DrainTimeout.AsDuration()
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		running.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		logHelper.Info("In-flight messages drained")
+	case <-time.After(drainTimeout):
+		logHelper.Warnf("Gave up waiting for in-flight messages after %s", drainTimeout)
+	}
 }
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// accountLocks serialises messages that touch the same account. A message
+// holds every account it touches while it is handled.
+type accountLocks struct {
+	mu   sync.Mutex
+	held map[string]chan struct{}
+}
+
+func newAccountLocks() *accountLocks {
+	return &accountLocks{held: make(map[string]chan struct{})}
+}
+
+// Lock blocks until none of keys is held and then takes them all. It returns
+// false if ctx is done first.
+func (l *accountLocks) Lock(ctx context.Context, keys []string) bool {
+	for {
+		l.mu.Lock()
+		var busy chan struct{}
+		for _, key := range keys {
+			if ch, ok := l.held[key]; ok {
+				busy = ch
+				break
+			}
+		}
+		if busy == nil {
+			for _, key := range keys {
+				l.held[key] = make(chan struct{})
+			}
+			l.mu.Unlock()
+			return true
+		}
+		l.mu.Unlock()
+
+		select {
+		case <-busy:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+func (l *accountLocks) Unlock(keys []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		if ch, ok := l.held[key]; ok {
+			close(ch)
+			delete(l.held, key)
+		}
+	}
+}
+
+// offsetTracker marks a partition's messages in offset order, so the committed
+// offset never passes a message that is still being handled.
+type offsetTracker struct {
+	session sarama.ConsumerGroupSession
+
+	mu      sync.Mutex
+	pending []*trackedOffset
+}
+
+type trackedOffset struct {
+	message *sarama.ConsumerMessage
+	done    bool
+}
+
+func newOffsetTracker(session sarama.ConsumerGroupSession) *offsetTracker {
+	return &offsetTracker{session: session}
+}
+
+// Start records a message as in flight and returns the handle to complete it with.
+func (t *offsetTracker) Start(message *sarama.ConsumerMessage) *trackedOffset {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	o := &trackedOffset{message: message}
+	t.pending = append(t.pending, o)
+	return o
+}
+
+// Done completes a message and marks every leading completed message.
+func (t *offsetTracker) Done(o *trackedOffset) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	o.done = true
+
+	var last *sarama.ConsumerMessage
+	for len(t.pending) > 0 && t.pending[0].done {
+		last = t.pending[0].message
+		t.pending = t.pending[1:]
+	}
+	if last != nil {
+		t.session.MarkMessage(last, "")
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/IBM/sarama"
+)
+
+// markSession records the offsets marked through it; other session methods
+// are not used by offsetTracker and panic through the nil embedded interface.
+type markSession struct {
+	sarama.ConsumerGroupSession
+	marked []int64
+}
+
+func (s *markSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+
+func TestOffsetTrackerMarksContiguousPrefix(t *testing.T) {
+	tests := []struct {
+		name string
+		// started is how many messages, offsets 100 up, are in flight.
+		started int
+		// done completes messages by index, in this order.
+		done []int
+		// want is the offsets marked after each completion, nil when none.
+		want [][]int64
+	}{
+		{
+			name:    "in order",
+			started: 3,
+			done:    []int{0, 1, 2},
+			want:    [][]int64{{100}, {101}, {102}},
+		},
+		{
+			name:    "reverse order waits for the first",
+			started: 3,
+			done:    []int{2, 1, 0},
+			want:    [][]int64{nil, nil, {102}},
+		},
+		{
+			name:    "gap holds later messages",
+			started: 4,
+			done:    []int{0, 2, 3, 1},
+			want:    [][]int64{{100}, nil, nil, {103}},
+		},
+		{
+			name:    "middle first",
+			started: 3,
+			done:    []int{1, 0, 2},
+			want:    [][]int64{nil, {101}, {102}},
+		},
+		{
+			name:    "single message",
+			started: 1,
+			done:    []int{0},
+			want:    [][]int64{{100}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &markSession{}
+			tracker := newOffsetTracker(session)
+
+			handles := make([]*trackedOffset, tt.started)
+			for i := range handles {
+				handles[i] = tracker.Start(&sarama.ConsumerMessage{Topic: "transactions", Partition: 0, Offset: int64(100 + i)})
+			}
+
+			for step, i := range tt.done {
+				before := len(session.marked)
+				tracker.Done(handles[i])
+
+				var got []int64
+				if len(session.marked) > before {
+					got = session.marked[before:]
+				}
+				if !reflect.DeepEqual(got, tt.want[step]) {
+					t.Fatalf("after completing message %d, marked %v, want %v", i, got, tt.want[step])
+				}
+			}
+
+			if len(tracker.pending) != 0 {
+				t.Fatalf("%d messages still pending after all completed", len(tracker.pending))
+			}
+		})
+	}
+}
+
+func TestOffsetTrackerStartAfterDrain(t *testing.T) {
+	session := &markSession{}
+	tracker := newOffsetTracker(session)
+
+	first := tracker.Start(&sarama.ConsumerMessage{Offset: 1})
+	tracker.Done(first)
+	second := tracker.Start(&sarama.ConsumerMessage{Offset: 2})
+	third := tracker.Start(&sarama.ConsumerMessage{Offset: 3})
+	tracker.Done(third)
+	tracker.Done(second)
+
+	if want := []int64{1, 3}; !reflect.DeepEqual(session.marked, want) {
+		t.Fatalf("marked %v, want %v", session.marked, want)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"bank-ledger/internal/biz"
+	"bank-ledger/internal/conf"
+	"bank-ledger/internal/data"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	// flagconf is the config path, for the MySQL and MongoDB settings.
+	flagconf string
+	// out is the file the discrepancy report is written to, stdout when empty.
+	out string
+	// repair rewrites missing or divergent log documents from MySQL.
+	repair bool
+	// orphans also reports log documents without a MySQL transaction.
+	orphans bool
+	// settle skips transactions changed within this window.
+	settle time.Duration
+)
+
+func init() {
+	flag.StringVar(&flagconf, "conf", "../../configs", "config path, eg: -conf config.yaml")
+	flag.StringVar(&out, "out", "", "file to write the discrepancy report to, defaults to stdout")
+	flag.BoolVar(&repair, "repair", false, "repair missing or divergent MongoDB logs from MySQL")
+	flag.BoolVar(&orphans, "orphans", true, "report MongoDB logs without a MySQL transaction")
+	flag.DurationVar(&settle, "settle", time.Minute, "skip transactions changed within this window")
+}
+
+// main exits with status 2 when discrepancies remain unrepaired, so schedulers
+// can tell them apart from failures to run.
+func main() {
+	flag.Parse()
+
+	remaining, err := run(context.Background())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if remaining > 0 {
+		os.Exit(2)
+	}
+}
+
+func run(ctx context.Context) (int, error) {
+	c := config.New(config.WithSource(file.NewSource(flagconf)))
+	defer c.Close()
+	if err := c.Load(); err != nil {
+		return 0, err
+	}
+	var bc conf.Bootstrap
+	if err := c.Scan(&bc); err != nil {
+		return 0, err
+	}
+
+	logger := log.With(log.NewStdLogger(os.Stderr), "ts", log.DefaultTimestamp)
+
+	dataData, cleanup, err := data.NewData(bc.Data, logger)
+	if err != nil {
+		return 0, err
+	}
+	defer cleanup()
+	database, cleanup2, err := data.NewMongoDBConnection(bc.Data, logger)
+	if err != nil {
+		return 0, err
+	}
+	defer cleanup2()
+
+	checker := biz.NewConsistencyChecker(
+		data.NewTransactionRepo(dataData, logger),
+		data.NewTransactionLogsRepo(dataData, logger, database),
+		logger,
+	)
+	report, err := checker.Check(ctx, biz.ConsistencyOptions{SettleTime: settle, Orphans: orphans, Repair: repair})
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}.Marshal(report)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal report: %w", err)
+	}
+	body = append(body, '\n')
+	if out == "" {
+		_, err = os.Stdout.Write(body)
+	} else {
+		err = os.WriteFile(out, body, 0o644)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to write report: %w", err)
+	}
+
+	remaining := 0
+	for _, discrepancy := range report.Discrepancies {
+		if !discrepancy.Repaired {
+			remaining++
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%d transactions and %d log documents checked, %d discrepancies, %d unrepaired\n",
+		report.TransactionsChecked, report.LogDocumentsChecked, len(report.Discrepancies), remaining)
+	return remaining, nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"bank-ledger/internal/biz"
+	"bank-ledger/internal/conf"
+	"bank-ledger/internal/data"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	// flagconf is the config path, for the database and webhook settings.
+	flagconf string
+	// out is the file the discrepancy report is written to, stdout when empty.
+	out string
+	// freeze sets mismatched ACTIVE accounts to FROZEN.
+	freeze bool
+	// alert sends an account.balance_mismatch webhook per discrepancy.
+	alert bool
+)
+
+func init() {
+	flag.StringVar(&flagconf, "conf", "../../configs", "config path, eg: -conf config.yaml")
+	flag.StringVar(&out, "out", "", "file to write the discrepancy report to, defaults to stdout")
+	flag.BoolVar(&freeze, "freeze", false, "freeze active accounts whose balance does not match")
+	flag.BoolVar(&alert, "alert", false, "send an account.balance_mismatch webhook per discrepancy")
+}
+
+// main exits with status 2 when discrepancies are found, so schedulers can
+// tell them apart from failures to run.
+func main() {
+	flag.Parse()
+
+	found, err := run(context.Background())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if found > 0 {
+		os.Exit(2)
+	}
+}
+
+func run(ctx context.Context) (int, error) {
+	c := config.New(config.WithSource(file.NewSource(flagconf)))
+	defer c.Close()
+	if err := c.Load(); err != nil {
+		return 0, err
+	}
+	var bc conf.Bootstrap
+	if err := c.Scan(&bc); err != nil {
+		return 0, err
+	}
+
+	logger := log.With(log.NewStdLogger(os.Stderr), "ts", log.DefaultTimestamp)
+
+	dataData, cleanup, err := data.NewData(bc.Data, logger)
+	if err != nil {
+		return 0, err
+	}
+	defer cleanup()
+
+	reconciler := biz.NewReconciler(
+		dataData,
+		data.NewAccountRepo(dataData, logger),
+		data.NewTransactionRepo(dataData, logger),
+		biz.NewWebhookHandler(bc.Server, data.NewWebhookRepo(dataData, logger), logger),
+		logger,
+	)
+	report, err := reconciler.Reconcile(ctx, biz.ReconcileOptions{Freeze: freeze, Alert: alert})
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}.Marshal(report)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal report: %w", err)
+	}
+	body = append(body, '\n')
+	if out == "" {
+		_, err = os.Stdout.Write(body)
+	} else {
+		err = os.WriteFile(out, body, 0o644)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to write report: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "%d accounts checked, %d discrepancies\n", report.AccountsChecked, len(report.Discrepancies))
+	return len(report.Discrepancies), nil
+}
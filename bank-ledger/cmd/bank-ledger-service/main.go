@@ -1,11 +1,16 @@
 package main
 
 import (
+	v1 "bank-ledger/api/bankLedger/v1"
 	"bank-ledger/internal/biz"
 	"bank-ledger/internal/data"
 	"bank-ledger/internal/kafka"
+	"bank-ledger/internal/metrics"
+	"bank-ledger/internal/propagation"
 	"bank-ledger/internal/server"
 	"bank-ledger/internal/service"
+	"bank-ledger/internal/telemetry"
+	"context"
 	"flag"
 	"os"
 
@@ -38,7 +43,7 @@ func init() {
 	flag.StringVar(&flagconf, "conf", "./configs", "config path, eg: -conf config.yaml")
 }
 
-func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
+func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server, sw *server.ScheduleWorker, bw *server.BatchWorker, el *server.TransactionEventListener, ww *server.WebhookWorker, ow *server.OutboxWorker, tw *server.SweeperWorker, nw *server.SnapshotWorker) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -48,6 +53,13 @@ func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
 		kratos.Server(
 			gs,
 			hs,
+			sw,
+			bw,
+			el,
+			ww,
+			ow,
+			tw,
+			nw,
 		),
 	)
 }
@@ -62,6 +74,8 @@ func main() {
 		"service.version", Version,
 		"trace.id", tracing.TraceID(),
 		"span.id", tracing.SpanID(),
+		"request.id", propagation.RequestID(),
+		"correlation.id", propagation.CorrelationID(),
 	)
 	c := config.New(
 		config.WithSource(
@@ -79,6 +93,12 @@ func main() {
 		panic(err)
 	}
 
+	shutdownTracing, err := telemetry.NewTracerProvider(bc.Tracing, Name, Version, id)
+	if err != nil {
+		panic(err)
+	}
+	defer shutdownTracing()
+
 	app, cleanup, err := wireApp(bc.Server, bc.Data, logger)
 	if err != nil {
 		panic(err)
@@ -97,26 +117,81 @@ func wireApp(confServer *conf.Server, confData *conf.Data, logger log.Logger) (*
 		return nil, nil, err
 	}
 	accountRepository := data.NewAccountRepo(dataData, logger)
-	accountHandler := biz.NewAccountHandler(accountRepository, logger)
-	accountService := service.NewAccountService(accountHandler)
+	webhookRepository := data.NewWebhookRepo(dataData, logger)
+	webhookHandler := biz.NewWebhookHandler(confServer, webhookRepository, logger)
+	outboxRepository := data.NewOutboxRepo(dataData, logger)
+	accountHandler := biz.NewAccountHandler(dataData, accountRepository, outboxRepository, webhookHandler, logger)
 	producer, err := kafka.NewProducer(confData, logger)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
+	topics := biz.NewTopics(confData)
 	transactionRepository := data.NewTransactionRepo(dataData, logger)
+	snapshotRepository := data.NewSnapshotRepo(dataData, logger)
+	snapshotHandler := biz.NewSnapshotHandler(confServer, snapshotRepository, accountRepository, transactionRepository, logger)
+	accountService := service.NewAccountService(accountHandler, snapshotHandler)
 	database, cleanup2, err := data.NewMongoDBConnection(confData, logger)
 	if err != nil {
+		producer.Close()
 		cleanup()
 		return nil, nil, err
 	}
 	transactionLogsRepository := data.NewTransactionLogsRepo(dataData, logger, database)
-	transactionHandler := biz.NewTransactionHandler(producer, logger, accountRepository, transactionRepository, transactionLogsRepository)
-	transactionService := service.NewTransactionService(transactionHandler)
-	grpcServer := server.NewGRPCServer(confServer, accountService, transactionService, logger)
-	httpServer := server.NewHTTPServer(confServer, accountService, transactionService, logger)
-	app := newApp(logger, grpcServer, httpServer)
+	sequenceRepository := data.NewSequenceRepo(dataData, logger)
+	transactionFailures := biz.NewTransactionFailures(dataData, transactionRepository, transactionLogsRepository, outboxRepository, producer, topics, webhookHandler, logger)
+	transactionHandler := biz.NewTransactionHandler(producer, topics, logger, accountRepository, transactionRepository, transactionLogsRepository, sequenceRepository, transactionFailures)
+	subscriber, cleanup3, err := kafka.NewSubscriber(confData, logger)
+	if err != nil {
+		producer.Close()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	transactionWatcher := biz.NewTransactionWatcher(transactionRepository, transactionLogsRepository, logger)
+	transactionService := service.NewTransactionService(transactionHandler, transactionWatcher)
+	scheduleRepository := data.NewScheduleRepo(dataData, logger)
+	scheduleHandler := biz.NewScheduleHandler(scheduleRepository, accountRepository, transactionHandler, logger)
+	scheduleService := service.NewScheduleService(scheduleHandler)
+	batchRepository := data.NewBatchRepo(dataData, logger)
+	batchHandler := biz.NewBatchHandler(batchRepository, accountRepository, transactionRepository, transactionHandler, logger)
+	batchService := service.NewBatchService(batchHandler)
+	webhookService := service.NewWebhookService(webhookHandler)
+	metricsMiddleware, err := metrics.NewServerMiddleware()
+	if err != nil {
+		cleanup3()
+		producer.Close()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	if err := metrics.RegisterOpenAccounts(func(ctx context.Context) (int64, error) {
+		return accountRepository.CountByStatus(ctx, v1.AccountStatus_ACTIVE.String())
+	}); err != nil {
+		cleanup3()
+		producer.Close()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	grpcServer := server.NewGRPCServer(confServer, accountService, transactionService, scheduleService, batchService, webhookService, metricsMiddleware, logger)
+	httpServer := server.NewHTTPServer(confServer, accountService, transactionService, scheduleService, batchService, webhookService, metricsMiddleware, logger)
+	scheduleWorker := server.NewScheduleWorker(confServer, scheduleHandler, logger)
+	batchWorker := server.NewBatchWorker(confServer, batchHandler, logger)
+	transactionEventListener := server.NewTransactionEventListener(topics, subscriber, transactionWatcher, logger)
+	webhookWorker := server.NewWebhookWorker(confServer, webhookHandler, logger)
+	outboxRelay := biz.NewOutboxRelay(confServer, dataData, outboxRepository, producer, topics, logger)
+	outboxWorker := server.NewOutboxWorker(confServer, outboxRelay, logger)
+	transactionSweeper := biz.NewTransactionSweeper(confServer, dataData, transactionRepository, transactionLogsRepository, producer, topics, transactionFailures, logger)
+	sweeperWorker := server.NewSweeperWorker(confServer, transactionSweeper, logger)
+	snapshotWorker := server.NewSnapshotWorker(confServer, snapshotHandler, logger)
+	app := newApp(logger, grpcServer, httpServer, scheduleWorker, batchWorker, transactionEventListener, webhookWorker, outboxWorker, sweeperWorker, snapshotWorker)
 	return app, func() {
+		cleanup3()
+		// Closing flushes buffered messages; delivery failures it reports
+		// still need the databases.
+		producer.Close()
+		transactionFailures.Wait()
 		cleanup2()
 		cleanup()
 	}, nil
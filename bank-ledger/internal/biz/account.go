@@ -6,28 +6,35 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"strconv"
+	"strings"
 	"time"
 
 	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/rs/xid"
+	"gorm.io/gorm"
 )
 
 type AccountHandler interface {
 	Create(ctx context.Context, req *v1.CreateAccountRequest) (*v1.AccountResponse, error)
 	Update(ctx context.Context, req *v1.UpdateAccountRequest) (*v1.AccountResponse, error)
 	FindByID(ctx context.Context, req *v1.BaseRequest) (*v1.AccountResponse, error)
-	ListAll(ctx context.Context) ([]*v1.AccountResponse, error)
+	List(ctx context.Context, req *v1.ListAccountsRequest) (*v1.GetAllAccountsResponse, error)
 	Delete(ctx context.Context, req *v1.BaseRequest) error
 }
 
 type Account struct {
-	repo data.AccountRepository
-	log  *log.Helper
+	data     *data.Data
+	repo     data.AccountRepository
+	outbox   data.OutboxRepository
+	webhooks WebhookHandler
+	log      *log.Helper
 }
 
-func NewAccountHandler(repo data.AccountRepository, logger log.Logger) AccountHandler {
-	return &Account{repo: repo, log: log.NewHelper(logger)}
+func NewAccountHandler(d *data.Data, repo data.AccountRepository, outbox data.OutboxRepository, webhooks WebhookHandler, logger log.Logger) AccountHandler {
+	return &Account{data: d, repo: repo, outbox: outbox, webhooks: webhooks, log: log.NewHelper(logger)}
 }
 
 func generateAccountNumber() string {
@@ -46,11 +53,19 @@ func (uc *Account) Create(ctx context.Context, req *v1.CreateAccountRequest) (*v
 		Status:        v1.AccountStatus_ACTIVE.String(),
 	}
 
-	if err := uc.repo.Create(ctx, acc); err != nil {
+	err := uc.data.DB().Transaction(func(tx *gorm.DB) error {
+		if err := uc.repo.WithTx(tx).Create(ctx, acc); err != nil {
+			return err
+		}
+		return WriteEvents(ctx, uc.outbox.WithTx(tx), AccountOpenedEvent(acc))
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return toProtoAccount(acc), nil
+	resp := toProtoAccount(acc)
+	uc.notify(ctx, WebhookEventAccountCreated, resp)
+	return resp, nil
 }
 
 func (uc *Account) Update(ctx context.Context, req *v1.UpdateAccountRequest) (*v1.AccountResponse, error) {
@@ -59,6 +74,7 @@ func (uc *Account) Update(ctx context.Context, req *v1.UpdateAccountRequest) (*v
 		return nil, err
 	}
 
+	existing := acc
 	acc = &entity.Account{
 		ID:            req.Id,
 		Name:          req.Name,
@@ -67,10 +83,17 @@ func (uc *Account) Update(ctx context.Context, req *v1.UpdateAccountRequest) (*v
 		CreatedAt:     acc.CreatedAt,
 		UpdatedAt:     time.Now(),
 	}
-	if err := uc.repo.Update(ctx, acc); err != nil {
+	if err := uc.update(ctx, existing, acc); err != nil {
 		return nil, err
 	}
-	return toProtoAccount(acc), nil
+
+	resp := toProtoAccount(acc)
+	if acc.Status == v1.AccountStatus_CLOSED.String() {
+		uc.notify(ctx, WebhookEventAccountClosed, resp)
+	} else {
+		uc.notify(ctx, WebhookEventAccountUpdated, resp)
+	}
+	return resp, nil
 }
 
 func (uc *Account) FindByID(ctx context.Context, req *v1.BaseRequest) (*v1.AccountResponse, error) {
@@ -81,16 +104,57 @@ func (uc *Account) FindByID(ctx context.Context, req *v1.BaseRequest) (*v1.Accou
 	return toProtoAccount(acc), nil
 }
 
-func (uc *Account) ListAll(ctx context.Context) ([]*v1.AccountResponse, error) {
-	accs, err := uc.repo.ListAll(ctx)
+func (uc *Account) List(ctx context.Context, req *v1.ListAccountsRequest) (*v1.GetAllAccountsResponse, error) {
+	sort, orderBy, err := parseAccountOrderBy(req.OrderBy)
 	if err != nil {
 		return nil, err
 	}
+
+	filter, err := accountFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var after *data.AccountCursor
+	if req.PageToken != "" {
+		var pt accountPageToken
+		if !decodeToken(req.PageToken, &pt) || pt.ID == "" || pt.OrderBy != orderBy {
+			return nil, errors.BadRequest("INVALID_PAGE_TOKEN", "page_token is invalid for this order_by")
+		}
+		value, err := accountCursorValue(sort.Field, pt.Value)
+		if err != nil {
+			return nil, errors.BadRequest("INVALID_PAGE_TOKEN", "page_token is invalid")
+		}
+		after = &data.AccountCursor{Value: value, ID: pt.ID}
+	}
+
+	pageSize := normalizePageSize(req.PageSize)
+
+	// Fetch one extra row to learn whether another page follows.
+	accs, err := uc.repo.FindPage(ctx, filter, sort, after, pageSize+1)
+	if err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+
+	var nextPageToken string
+	if len(accs) > pageSize {
+		accs = accs[:pageSize]
+		last := accs[len(accs)-1]
+		nextPageToken = encodeToken(accountPageToken{
+			OrderBy: orderBy,
+			Value:   accountSortValue(sort.Field, last),
+			ID:      last.ID,
+		})
+	}
+
 	resp := make([]*v1.AccountResponse, 0, len(accs))
 	for _, acc := range accs {
 		resp = append(resp, toProtoAccount(acc))
 	}
-	return resp, nil
+	return &v1.GetAllAccountsResponse{
+		Accounts:      resp,
+		NextPageToken: nextPageToken,
+	}, nil
 }
 
 func (uc *Account) Delete(ctx context.Context, req *v1.BaseRequest) error {
@@ -99,6 +163,7 @@ func (uc *Account) Delete(ctx context.Context, req *v1.BaseRequest) error {
 		return err
 	}
 
+	existing := acc
 	acc = &entity.Account{
 		ID:        req.Id,
 		Name:      acc.Name,
@@ -106,12 +171,39 @@ func (uc *Account) Delete(ctx context.Context, req *v1.BaseRequest) error {
 		CreatedAt: acc.CreatedAt,
 		UpdatedAt: time.Now(),
 	}
-	if err = uc.repo.Update(ctx, acc); err != nil {
+	if err = uc.update(ctx, existing, acc); err != nil {
 		return err
 	}
+
+	uc.notify(ctx, WebhookEventAccountClosed, toProtoAccount(acc))
 	return nil
 }
 
+// update saves acc and, when it closes a previously open account, records an
+// AccountClosed event in the same transaction.
+func (uc *Account) update(ctx context.Context, existing *entity.Account, acc *entity.Account) error {
+	return uc.data.DB().Transaction(func(tx *gorm.DB) error {
+		if err := uc.repo.WithTx(tx).Update(ctx, acc); err != nil {
+			return err
+		}
+		if existing.Status == v1.AccountStatus_CLOSED.String() || acc.Status != v1.AccountStatus_CLOSED.String() {
+			return nil
+		}
+
+		closed := *existing
+		closed.Status = acc.Status
+		return WriteEvents(ctx, uc.outbox.WithTx(tx), AccountClosedEvent(&closed))
+	})
+}
+
+// notify queues a webhook event. Failures are logged rather than failing the
+// account change that triggered them.
+func (uc *Account) notify(ctx context.Context, eventType string, acc *v1.AccountResponse) {
+	if err := uc.webhooks.Enqueue(ctx, eventType, acc, acc.Id); err != nil {
+		uc.log.Errorf("failed to enqueue %s webhook for account %s: %v", eventType, acc.Id, err)
+	}
+}
+
 func toProtoAccount(acc *entity.Account) *v1.AccountResponse {
 	return &v1.AccountResponse{
 		Id:            acc.ID,
@@ -128,3 +220,84 @@ func toProtoAccount(acc *entity.Account) *v1.AccountResponse {
 func formatFloat(val float64) string {
 	return fmt.Sprintf("%.2f", val)
 }
+
+// parseAccountOrderBy validates order_by and returns it in canonical "field direction" form.
+func parseAccountOrderBy(orderBy string) (data.AccountSort, string, error) {
+	sort := data.AccountSort{Field: "created_at", Desc: true}
+
+	parts := strings.Fields(strings.ToLower(orderBy))
+	if len(parts) > 2 {
+		return sort, "", errors.BadRequest("INVALID_ORDER_BY", "order_by must be a field optionally followed by asc or desc")
+	}
+	if len(parts) > 0 {
+		switch parts[0] {
+		case "created_at", "name", "balance":
+			sort.Field = parts[0]
+			sort.Desc = false
+		default:
+			return sort, "", errors.BadRequest("INVALID_ORDER_BY", "order_by must be one of created_at, name or balance")
+		}
+	}
+	if len(parts) == 2 {
+		switch parts[1] {
+		case "asc":
+			sort.Desc = false
+		case "desc":
+			sort.Desc = true
+		default:
+			return sort, "", errors.BadRequest("INVALID_ORDER_BY", "order_by direction must be asc or desc")
+		}
+	}
+
+	direction := "asc"
+	if sort.Desc {
+		direction = "desc"
+	}
+	return sort, sort.Field + " " + direction, nil
+}
+
+func accountFilter(req *v1.ListAccountsRequest) (data.AccountFilter, error) {
+	filter := data.AccountFilter{NamePrefix: req.NamePrefix}
+
+	for _, status := range req.Statuses {
+		filter.Statuses = append(filter.Statuses, status.String())
+	}
+	if req.Currency != v1.Currency_CURRENCY_UNSPECIFIED {
+		filter.Currency = req.Currency.String()
+	}
+
+	var err error
+	if req.CreatedFrom != "" {
+		if filter.CreatedFrom, err = parseStatementTime(req.CreatedFrom, false); err != nil {
+			return filter, errors.BadRequest("INVALID_CREATED_FROM", "created_from must be an RFC3339 timestamp or YYYY-MM-DD date")
+		}
+	}
+	if req.CreatedTo != "" {
+		if filter.CreatedTo, err = parseStatementTime(req.CreatedTo, true); err != nil {
+			return filter, errors.BadRequest("INVALID_CREATED_TO", "created_to must be an RFC3339 timestamp or YYYY-MM-DD date")
+		}
+	}
+	return filter, nil
+}
+
+func accountSortValue(field string, acc *entity.Account) string {
+	switch field {
+	case "name":
+		return acc.Name
+	case "balance":
+		return strconv.FormatFloat(acc.Balance, 'f', -1, 64)
+	default:
+		return acc.CreatedAt.Format(time.RFC3339Nano)
+	}
+}
+
+func accountCursorValue(field string, value string) (interface{}, error) {
+	switch field {
+	case "name":
+		return value, nil
+	case "balance":
+		return strconv.ParseFloat(value, 64)
+	default:
+		return time.Parse(time.RFC3339Nano, value)
+	}
+}
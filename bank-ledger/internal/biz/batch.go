@@ -0,0 +1,301 @@
+package biz
+
+import (
+	"bank-ledger/internal/data"
+	"bank-ledger/internal/entity"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/rs/xid"
+)
+
+type BatchHandler interface {
+	Create(ctx context.Context, req *v1.CreateBatchRequest) (*v1.BatchResponse, error)
+	FindByID(ctx context.Context, req *v1.BaseRequest) (*v1.BatchResponse, error)
+	ProcessPending(ctx context.Context, limit int) (int, error)
+}
+
+type Batch struct {
+	repo data.BatchRepository
+	acc  data.AccountRepository
+	trx  data.TransactionRepository
+	txn  TransactionHandler
+	log  *log.Helper
+}
+
+func NewBatchHandler(repo data.BatchRepository, acc data.AccountRepository, trx data.TransactionRepository, txn TransactionHandler, logger log.Logger) BatchHandler {
+	return &Batch{
+		repo: repo,
+		acc:  acc,
+		trx:  trx,
+		txn:  txn,
+		log:  log.NewHelper(log.With(logger, "module", "biz/batch")),
+	}
+}
+
+func (b *Batch) Create(ctx context.Context, req *v1.CreateBatchRequest) (*v1.BatchResponse, error) {
+	if len(req.Content) == 0 {
+		return nil, errors.BadRequest("CONTENT_REQUIRED", "content is required")
+	}
+
+	lines, err := ParseBatchFile(req.Format, req.Content)
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_BATCH_FILE", err.Error())
+	}
+	if len(lines) == 0 {
+		return nil, errors.BadRequest("EMPTY_BATCH", "batch file contains no transactions")
+	}
+
+	batch := &entity.Batch{
+		ID:         xid.New().String(),
+		Name:       req.Name,
+		Format:     req.Format.String(),
+		Status:     v1.BatchStatus_BATCH_RECEIVED.String(),
+		TotalLines: len(lines),
+	}
+	for _, line := range lines {
+		line.BatchID = batch.ID
+		line.Status = v1.BatchLineStatus_LINE_PENDING.String()
+	}
+
+	if err := b.repo.Create(ctx, batch, lines); err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+
+	return b.toProtoBatch(ctx, batch, lines)
+}
+
+func (b *Batch) FindByID(ctx context.Context, req *v1.BaseRequest) (*v1.BatchResponse, error) {
+	batch, err := b.repo.FindByID(ctx, req)
+	if err != nil {
+		return nil, errors.NotFound("BATCH_NOT_FOUND", "batch not found")
+	}
+
+	lines, err := b.repo.FindLines(ctx, batch.ID)
+	if err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+
+	return b.toProtoBatch(ctx, batch, lines)
+}
+
+// ProcessPending validates and publishes up to limit pending lines across open
+// batches, returning the number of lines handled.
+func (b *Batch) ProcessPending(ctx context.Context, limit int) (int, error) {
+	batches, err := b.repo.FindByStatus(ctx, []string{
+		v1.BatchStatus_BATCH_RECEIVED.String(),
+		v1.BatchStatus_BATCH_PROCESSING.String(),
+	}, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find open batches: %w", err)
+	}
+
+	processed := 0
+	for _, batch := range batches {
+		if processed >= limit {
+			break
+		}
+		n, err := b.processBatch(ctx, batch, limit-processed)
+		processed += n
+		if err != nil {
+			b.log.Errorf("failed to process batch %s: %v", batch.ID, err)
+		}
+	}
+	return processed, nil
+}
+
+func (b *Batch) processBatch(ctx context.Context, batch *entity.Batch, limit int) (int, error) {
+	if batch.Status == v1.BatchStatus_BATCH_RECEIVED.String() {
+		batch.Status = v1.BatchStatus_BATCH_PROCESSING.String()
+		if err := b.repo.Update(ctx, batch); err != nil {
+			return 0, err
+		}
+	}
+
+	lines, err := b.repo.FindPendingLines(ctx, batch.ID, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	accounts := make(map[string]*entity.Account)
+	processed := 0
+	for _, line := range lines {
+		if err := b.submitLine(ctx, batch, line, accounts); err != nil {
+			// Leave the line pending so the next run retries it.
+			return processed, err
+		}
+		processed++
+	}
+
+	if len(lines) < limit {
+		batch.Status = v1.BatchStatus_BATCH_COMPLETED.String()
+		if err := b.repo.Update(ctx, batch); err != nil {
+			return processed, err
+		}
+		b.log.Infof("batch %s completed", batch.ID)
+	}
+	return processed, nil
+}
+
+func (b *Batch) submitLine(ctx context.Context, batch *entity.Batch, line *entity.BatchLine, accounts map[string]*entity.Account) error {
+	req, reason := b.validateLine(ctx, line, accounts)
+	if reason == "" {
+		req.IdempotencyKey = fmt.Sprintf("batch:%s:%d", batch.ID, line.LineNumber)
+		resp, err := b.txn.Create(ctx, req)
+		if err != nil {
+			if errors.FromError(err).Code >= http.StatusInternalServerError {
+				return err
+			}
+			reason = errors.FromError(err).Message
+		} else {
+			line.TransactionID = resp.TransactionId
+		}
+	}
+
+	if reason != "" {
+		line.Status = v1.BatchLineStatus_LINE_REJECTED.String()
+		line.Error = reason
+	} else {
+		line.Status = v1.BatchLineStatus_LINE_SUBMITTED.String()
+		line.Error = ""
+	}
+	return b.repo.UpdateLine(ctx, line)
+}
+
+// validateLine checks a line against the ledger's accounts and returns either
+// the transaction request to publish or the reason the line is rejected.
+func (b *Batch) validateLine(ctx context.Context, line *entity.BatchLine, accounts map[string]*entity.Account) (*v1.CreateTransactionRequest, string) {
+	if line.Amount <= 0 {
+		return nil, "amount must be positive"
+	}
+
+	txType := v1.TransactionType(v1.TransactionType_value[line.Type])
+	switch txType {
+	case v1.TransactionType_DEPOSIT, v1.TransactionType_WITHDRAWAL, v1.TransactionType_TRANSFER:
+	default:
+		return nil, fmt.Sprintf("transaction type %s is not allowed in batches", line.Type)
+	}
+
+	account := b.resolveAccount(ctx, line.AccountRef, accounts)
+	if account == nil {
+		return nil, fmt.Sprintf("account %s does not exist", line.AccountRef)
+	}
+	if account.Status == v1.AccountStatus_CLOSED.String() {
+		return nil, fmt.Sprintf("account %s is closed", line.AccountRef)
+	}
+	if line.Currency != "" && line.Currency != account.Currency {
+		return nil, fmt.Sprintf("currency %s does not match account currency %s", line.Currency, account.Currency)
+	}
+
+	req := &v1.CreateTransactionRequest{
+		AccountId:   account.ID,
+		Amount:      line.Amount,
+		Type:        txType,
+		Description: line.Description,
+	}
+
+	if txType == v1.TransactionType_TRANSFER {
+		counterparty := b.resolveAccount(ctx, line.CounterpartyRef, accounts)
+		if counterparty == nil {
+			return nil, fmt.Sprintf("counterparty account %s does not exist", line.CounterpartyRef)
+		}
+		if counterparty.Status == v1.AccountStatus_CLOSED.String() {
+			return nil, fmt.Sprintf("counterparty account %s is closed", line.CounterpartyRef)
+		}
+		if counterparty.Currency != account.Currency {
+			return nil, "counterparty account currency does not match"
+		}
+		req.CounterpartyAccountId = counterparty.ID
+	}
+
+	return req, ""
+}
+
+// resolveAccount looks an account up by ID or account number, caching results
+// for the rest of the batch run.
+func (b *Batch) resolveAccount(ctx context.Context, ref string, accounts map[string]*entity.Account) *entity.Account {
+	if ref == "" {
+		return nil
+	}
+	if acc, ok := accounts[ref]; ok {
+		return acc
+	}
+
+	acc, err := b.acc.FindByID(ctx, &v1.BaseRequest{Id: ref})
+	if err != nil {
+		acc, err = b.acc.FindByAccountNumber(ctx, ref)
+		if err != nil {
+			acc = nil
+		}
+	}
+	accounts[ref] = acc
+	return acc
+}
+
+func (b *Batch) toProtoBatch(ctx context.Context, batch *entity.Batch, lines []*entity.BatchLine) (*v1.BatchResponse, error) {
+	var ids []string
+	for _, line := range lines {
+		if line.TransactionID != "" {
+			ids = append(ids, line.TransactionID)
+		}
+	}
+
+	trxs, err := b.trx.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+	statuses := make(map[string]string, len(trxs))
+	for _, tx := range trxs {
+		statuses[tx.ID] = tx.Status
+	}
+
+	progress := &v1.BatchProgress{TotalLines: int32(batch.TotalLines)}
+	protoLines := make([]*v1.BatchLine, 0, len(lines))
+	for _, line := range lines {
+		txStatus := v1.TransactionStatus(v1.TransactionStatus_value[statuses[line.TransactionID]])
+		switch line.Status {
+		case v1.BatchLineStatus_LINE_PENDING.String():
+			progress.PendingLines++
+		case v1.BatchLineStatus_LINE_REJECTED.String():
+			progress.RejectedLines++
+		case v1.BatchLineStatus_LINE_SUBMITTED.String():
+			progress.SubmittedLines++
+			switch txStatus {
+			case v1.TransactionStatus_SUCCESS:
+				progress.SucceededLines++
+			case v1.TransactionStatus_FAILED:
+				progress.FailedLines++
+			}
+		}
+
+		protoLines = append(protoLines, &v1.BatchLine{
+			LineNumber:          int32(line.LineNumber),
+			Account:             line.AccountRef,
+			CounterpartyAccount: line.CounterpartyRef,
+			Amount:              line.Amount,
+			Currency:            line.Currency,
+			Type:                v1.TransactionType(v1.TransactionType_value[line.Type]),
+			Description:         line.Description,
+			Status:              v1.BatchLineStatus(v1.BatchLineStatus_value[line.Status]),
+			Error:               line.Error,
+			TransactionId:       line.TransactionID,
+			TransactionStatus:   txStatus,
+		})
+	}
+
+	return &v1.BatchResponse{
+		Id:        batch.ID,
+		Name:      batch.Name,
+		Format:    v1.BatchFormat(v1.BatchFormat_value[batch.Format]),
+		Status:    v1.BatchStatus(v1.BatchStatus_value[batch.Status]),
+		Progress:  progress,
+		Lines:     protoLines,
+		CreatedAt: batch.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: batch.UpdatedAt.Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,166 @@
+package biz
+
+import (
+	"bank-ledger/internal/entity"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+)
+
+// csvBatchHeader is the column layout expected from CSV batch files.
+var csvBatchHeader = []string{"type", "account", "counterparty_account", "amount", "currency", "description"}
+
+// ParseBatchFile turns an uploaded batch file into pending batch lines.
+func ParseBatchFile(format v1.BatchFormat, content []byte) ([]*entity.BatchLine, error) {
+	switch format {
+	case v1.BatchFormat_PAIN_001:
+		return parsePain001(content)
+	case v1.BatchFormat_CSV:
+		return parseBatchCSV(content)
+	default:
+		return nil, fmt.Errorf("unsupported batch format: %s", format)
+	}
+}
+
+type pain001Document struct {
+	Initiation struct {
+		GroupHeader struct {
+			MsgID       string `xml:"MsgId"`
+			NumberOfTxs string `xml:"NbOfTxs"`
+			ControlSum  string `xml:"CtrlSum"`
+		} `xml:"GrpHdr"`
+		PaymentInfos []struct {
+			DebtorAccount pain001Account `xml:"DbtrAcct"`
+			Transfers     []struct {
+				EndToEndID string `xml:"PmtId>EndToEndId"`
+				Amount     struct {
+					Currency string `xml:"Ccy,attr"`
+					Value    string `xml:",chardata"`
+				} `xml:"Amt>InstdAmt"`
+				CreditorAccount pain001Account `xml:"CdtrAcct"`
+				Remittance      string         `xml:"RmtInf>Ustrd"`
+			} `xml:"CdtTrfTxInf"`
+		} `xml:"PmtInf"`
+	} `xml:"CstmrCdtTrfInitn"`
+}
+
+type pain001Account struct {
+	IBAN  string `xml:"Id>IBAN"`
+	Other string `xml:"Id>Othr>Id"`
+}
+
+func (a pain001Account) ref() string {
+	if a.IBAN != "" {
+		return strings.TrimSpace(a.IBAN)
+	}
+	return strings.TrimSpace(a.Other)
+}
+
+func parsePain001(content []byte) ([]*entity.BatchLine, error) {
+	var doc pain001Document
+	if err := xml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("invalid pain.001 document: %w", err)
+	}
+
+	var lines []*entity.BatchLine
+	var total float64
+	for _, info := range doc.Initiation.PaymentInfos {
+		debtor := info.DebtorAccount.ref()
+		for _, transfer := range info.Transfers {
+			lineNumber := len(lines) + 1
+			amount, err := strconv.ParseFloat(strings.TrimSpace(transfer.Amount.Value), 64)
+			if err != nil {
+				return nil, fmt.Errorf("transaction %d: invalid amount %q", lineNumber, transfer.Amount.Value)
+			}
+			total += amount
+
+			description := transfer.Remittance
+			if description == "" {
+				description = transfer.EndToEndID
+			}
+			lines = append(lines, &entity.BatchLine{
+				LineNumber:      lineNumber,
+				AccountRef:      debtor,
+				CounterpartyRef: transfer.CreditorAccount.ref(),
+				Amount:          amount,
+				Currency:        transfer.Amount.Currency,
+				Type:            v1.TransactionType_TRANSFER.String(),
+				Description:     description,
+			})
+		}
+	}
+
+	header := doc.Initiation.GroupHeader
+	if header.NumberOfTxs != "" {
+		if n, err := strconv.Atoi(strings.TrimSpace(header.NumberOfTxs)); err != nil || n != len(lines) {
+			return nil, fmt.Errorf("NbOfTxs %q does not match %d transactions in the file", header.NumberOfTxs, len(lines))
+		}
+	}
+	if header.ControlSum != "" {
+		sum, err := strconv.ParseFloat(strings.TrimSpace(header.ControlSum), 64)
+		if err != nil || math.Abs(sum-total) > 0.005 {
+			return nil, fmt.Errorf("CtrlSum %q does not match transaction total %.2f", header.ControlSum, total)
+		}
+	}
+
+	return lines, nil
+}
+
+func parseBatchCSV(content []byte) ([]*entity.BatchLine, error) {
+	r := csv.NewReader(bytes.NewReader(content))
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range csvBatchHeader {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv header is missing column %q, expected %s", name, strings.Join(csvBatchHeader, ","))
+		}
+	}
+
+	var lines []*entity.BatchLine
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		lineNumber := len(lines) + 1
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		txType := strings.ToUpper(strings.TrimSpace(record[columns["type"]]))
+		if _, ok := v1.TransactionType_value[txType]; !ok {
+			return nil, fmt.Errorf("line %d: unknown transaction type %q", lineNumber, txType)
+		}
+
+		amount, err := strconv.ParseFloat(strings.TrimSpace(record[columns["amount"]]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount %q", lineNumber, record[columns["amount"]])
+		}
+
+		lines = append(lines, &entity.BatchLine{
+			LineNumber:      lineNumber,
+			AccountRef:      strings.TrimSpace(record[columns["account"]]),
+			CounterpartyRef: strings.TrimSpace(record[columns["counterparty_account"]]),
+			Amount:          amount,
+			Currency:        strings.ToUpper(strings.TrimSpace(record[columns["currency"]])),
+			Type:            txType,
+			Description:     strings.TrimSpace(record[columns["description"]]),
+		})
+	}
+	return lines, nil
+}
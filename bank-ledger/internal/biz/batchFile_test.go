@@ -0,0 +1,183 @@
+package biz
+
+import (
+	"bank-ledger/internal/entity"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+)
+
+func pain001(header string, transfers ...string) []byte {
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03">
+  <CstmrCdtTrfInitn>
+    <GrpHdr><MsgId>MSG-1</MsgId>%s</GrpHdr>
+    <PmtInf>
+      <DbtrAcct><Id><IBAN> DE89370400440532013000 </IBAN></Id></DbtrAcct>
+      %s
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>`, header, strings.Join(transfers, "\n")))
+}
+
+func pain001Transfer(endToEnd, amount, creditor, remittance string) string {
+	return fmt.Sprintf(`<CdtTrfTxInf>
+        <PmtId><EndToEndId>%s</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="EUR">%s</InstdAmt></Amt>
+        <CdtrAcct><Id><Othr><Id>%s</Id></Othr></Id></CdtrAcct>
+        <RmtInf><Ustrd>%s</Ustrd></RmtInf>
+      </CdtTrfTxInf>`, endToEnd, amount, creditor, remittance)
+}
+
+func TestParsePain001(t *testing.T) {
+	rent := pain001Transfer("E2E-1", "100.50", "acc-2", "rent")
+	fee := pain001Transfer("E2E-2", "20.25", "acc-3", "")
+	want := []*entity.BatchLine{
+		{LineNumber: 1, AccountRef: "DE89370400440532013000", CounterpartyRef: "acc-2", Amount: 100.5, Currency: "EUR", Type: v1.TransactionType_TRANSFER.String(), Description: "rent"},
+		{LineNumber: 2, AccountRef: "DE89370400440532013000", CounterpartyRef: "acc-3", Amount: 20.25, Currency: "EUR", Type: v1.TransactionType_TRANSFER.String(), Description: "E2E-2"},
+	}
+
+	tests := []struct {
+		name    string
+		content []byte
+		want    []*entity.BatchLine
+		wantErr string
+	}{
+		{
+			name:    "matching header",
+			content: pain001("<NbOfTxs>2</NbOfTxs><CtrlSum>120.75</CtrlSum>", rent, fee),
+			want:    want,
+		},
+		{
+			name:    "no control values",
+			content: pain001("", rent, fee),
+			want:    want,
+		},
+		{
+			name:    "NbOfTxs mismatch",
+			content: pain001("<NbOfTxs>3</NbOfTxs>", rent, fee),
+			wantErr: "NbOfTxs",
+		},
+		{
+			name:    "NbOfTxs not a number",
+			content: pain001("<NbOfTxs>two</NbOfTxs>", rent, fee),
+			wantErr: "NbOfTxs",
+		},
+		{
+			name:    "CtrlSum mismatch",
+			content: pain001("<CtrlSum>120.70</CtrlSum>", rent, fee),
+			wantErr: "CtrlSum",
+		},
+		{
+			name:    "CtrlSum within rounding",
+			content: pain001("<CtrlSum>120.754</CtrlSum>", rent, fee),
+			want:    want,
+		},
+		{
+			name:    "invalid amount",
+			content: pain001("", rent, pain001Transfer("E2E-2", "abc", "acc-3", "")),
+			wantErr: "transaction 2: invalid amount",
+		},
+		{
+			name:    "not xml",
+			content: []byte("type,account"),
+			wantErr: "invalid pain.001 document",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBatchFile(v1.BatchFormat_PAIN_001, tt.content)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("lines = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBatchCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []*entity.BatchLine
+		wantErr string
+	}{
+		{
+			name: "lines",
+			content: "type,account,counterparty_account,amount,currency,description\n" +
+				"deposit, acc-1,,50,usd, salary \n" +
+				"TRANSFER,acc-1,acc-2,12.5,USD,rent\n",
+			want: []*entity.BatchLine{
+				{LineNumber: 1, AccountRef: "acc-1", Amount: 50, Currency: "USD", Type: "DEPOSIT", Description: "salary"},
+				{LineNumber: 2, AccountRef: "acc-1", CounterpartyRef: "acc-2", Amount: 12.5, Currency: "USD", Type: "TRANSFER", Description: "rent"},
+			},
+		},
+		{
+			name: "columns in any order",
+			content: "Amount,Type,Account,Currency,Description,Counterparty_Account\n" +
+				"10,withdrawal,acc-1,EUR,atm,\n",
+			want: []*entity.BatchLine{
+				{LineNumber: 1, AccountRef: "acc-1", Amount: 10, Currency: "EUR", Type: "WITHDRAWAL", Description: "atm"},
+			},
+		},
+		{
+			name:    "missing column",
+			content: "type,account,amount,currency,description\n",
+			wantErr: `missing column "counterparty_account"`,
+		},
+		{
+			name:    "empty file",
+			content: "",
+			wantErr: "invalid csv header",
+		},
+		{
+			name: "unknown type",
+			content: "type,account,counterparty_account,amount,currency,description\n" +
+				"refund,acc-1,,5,USD,\n",
+			wantErr: `line 1: unknown transaction type "REFUND"`,
+		},
+		{
+			name: "invalid amount",
+			content: "type,account,counterparty_account,amount,currency,description\n" +
+				"DEPOSIT,acc-1,,5,USD,\n" +
+				"DEPOSIT,acc-1,,five,USD,\n",
+			wantErr: `line 2: invalid amount "five"`,
+		},
+		{
+			name: "wrong field count",
+			content: "type,account,counterparty_account,amount,currency,description\n" +
+				"DEPOSIT,acc-1,5\n",
+			wantErr: "line 1:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBatchFile(v1.BatchFormat_CSV, []byte(tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("lines = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
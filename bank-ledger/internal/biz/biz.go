@@ -3,4 +3,4 @@ package biz
 import "github.com/google/wire"
 
 // ProviderSet is biz providers.
-var ProviderSet = wire.NewSet(NewAccountHandler, NewTransactionHandler)
+var ProviderSet = wire.NewSet(NewAccountHandler, NewTransactionHandler, NewFeeEngine, NewScheduleHandler, NewBatchHandler, NewTransactionWatcher, NewWebhookHandler, NewOutboxRelay, NewTopics, NewTransactionSweeper, NewTransactionFailures, NewReconciler, NewConsistencyChecker, NewSnapshotHandler)
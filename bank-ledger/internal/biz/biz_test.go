@@ -0,0 +1,241 @@
+package biz
+
+import (
+	"bank-ledger/internal/data"
+	"bank-ledger/internal/entity"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// txDriver is a database/sql driver that only begins, commits and rolls back
+// transactions. Tests use it so code wrapping its work in DB().Transaction can
+// run against fake repositories; any statement reaching it fails.
+type txDriver struct{}
+
+type txConn struct{}
+
+type txTx struct{}
+
+func (txDriver) Open(string) (driver.Conn, error) { return txConn{}, nil }
+
+func (txConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("unexpected statement: " + query)
+}
+func (txConn) Close() error              { return nil }
+func (txConn) Begin() (driver.Tx, error) { return txTx{}, nil }
+
+func (txTx) Commit() error   { return nil }
+func (txTx) Rollback() error { return nil }
+
+var registerTxDriver sync.Once
+
+// newTestData returns a *data.Data whose transactions reach no database.
+func newTestData(t *testing.T) *data.Data {
+	t.Helper()
+	registerTxDriver.Do(func() { sql.Register("biz-test-tx", txDriver{}) })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DriverName:                "biz-test-tx",
+		DSN:                       "test",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	return data.NewDataFromDB(db, log.DefaultLogger)
+}
+
+// fakeAccounts keeps accounts in memory. Methods the tests do not need panic
+// through the nil embedded interface.
+type fakeAccounts struct {
+	data.AccountRepository
+	accounts map[string]*entity.Account
+	// onLock runs when an account row is locked, standing in for whatever
+	// another replica committed while this one waited for the lock.
+	onLock func(id string)
+}
+
+func newFakeAccounts(accounts ...*entity.Account) *fakeAccounts {
+	f := &fakeAccounts{accounts: make(map[string]*entity.Account)}
+	for _, acc := range accounts {
+		f.accounts[acc.ID] = acc
+	}
+	return f
+}
+
+func (f *fakeAccounts) WithTx(*gorm.DB) data.AccountRepository { return f }
+
+func (f *fakeAccounts) ListAll(context.Context) ([]*entity.Account, error) {
+	var accounts []*entity.Account
+	for _, acc := range f.accounts {
+		copied := *acc
+		accounts = append(accounts, &copied)
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].ID < accounts[j].ID })
+	return accounts, nil
+}
+
+func (f *fakeAccounts) FindByID(_ context.Context, req *v1.BaseRequest) (*entity.Account, error) {
+	acc, ok := f.accounts[req.Id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *acc
+	return &copied, nil
+}
+
+func (f *fakeAccounts) FindByIDForUpdate(ctx context.Context, id string) (*entity.Account, error) {
+	if f.onLock != nil {
+		f.onLock(id)
+	}
+	return f.FindByID(ctx, &v1.BaseRequest{Id: id})
+}
+
+func (f *fakeAccounts) Update(_ context.Context, req *entity.Account) error {
+	copied := *req
+	f.accounts[req.ID] = &copied
+	return nil
+}
+
+// fakeTransactions keeps transactions in memory and enforces the unique
+// idempotency key like the table does.
+type fakeTransactions struct {
+	data.TransactionRepository
+	transactions []*entity.Transaction
+}
+
+func (f *fakeTransactions) WithTx(*gorm.DB) data.TransactionRepository { return f }
+
+func (f *fakeTransactions) Create(_ context.Context, req *entity.Transaction) error {
+	if req.IdempotencyKey != nil {
+		for _, trx := range f.transactions {
+			if trx.IdempotencyKey != nil && *trx.IdempotencyKey == *req.IdempotencyKey {
+				return gorm.ErrDuplicatedKey
+			}
+		}
+	}
+	copied := *req
+	f.transactions = append(f.transactions, &copied)
+	return nil
+}
+
+func (f *fakeTransactions) FindByID(_ context.Context, req *v1.BaseRequest) (*entity.Transaction, error) {
+	for _, trx := range f.transactions {
+		if trx.ID == req.Id {
+			copied := *trx
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeTransactions) Update(_ context.Context, req *entity.Transaction) error {
+	for i, trx := range f.transactions {
+		if trx.ID == req.ID {
+			copied := *req
+			f.transactions[i] = &copied
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (f *fakeTransactions) CountFeesSince(_ context.Context, accountID string, rule string, since time.Time) (int64, error) {
+	var count int64
+	for _, trx := range f.transactions {
+		if trx.AccountID == accountID && trx.FeeRule == rule && !trx.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeTransactions) SumSettledBefore(ctx context.Context, accountID string, before time.Time) (float64, error) {
+	summary, err := f.SummarizeSettled(ctx, accountID, time.Time{}, before)
+	return summary.Credits - summary.Debits, err
+}
+
+func (f *fakeTransactions) SummarizeSettled(_ context.Context, accountID string, from time.Time, to time.Time) (data.TransactionSummary, error) {
+	var summary data.TransactionSummary
+	for _, trx := range f.transactions {
+		if trx.AccountID != accountID || trx.Status != v1.TransactionStatus_SUCCESS.String() {
+			continue
+		}
+		settled := trx.CreatedAt
+		if trx.ProcessedAt != nil {
+			settled = *trx.ProcessedAt
+		}
+		if settled.Before(from) || !settled.Before(to) {
+			continue
+		}
+		if trx.Type == v1.TransactionType_DEPOSIT.String() {
+			summary.Credits += trx.Amount
+			summary.CreditCount++
+		} else {
+			summary.Debits += trx.Amount
+			summary.DebitCount++
+		}
+	}
+	return summary, nil
+}
+
+func (f *fakeTransactions) LockStale(_ context.Context, status string, before time.Time, limit int) ([]*entity.Transaction, error) {
+	var stale []*entity.Transaction
+	for _, trx := range f.transactions {
+		if trx.Status == status && trx.UpdatedAt.Before(before) && len(stale) < limit {
+			copied := *trx
+			stale = append(stale, &copied)
+		}
+	}
+	return stale, nil
+}
+
+func (f *fakeTransactions) CountStale(ctx context.Context, status string, before time.Time) (int64, error) {
+	stale, err := f.LockStale(ctx, status, before, len(f.transactions))
+	return int64(len(stale)), err
+}
+
+// fakeLogs serves a single transaction log and discards appended entries.
+type fakeLogs struct {
+	data.TransactionLogsRepository
+	log *entity.TransactionLog
+	// onGet runs before the log is read, standing in for whatever the
+	// consumer recorded and published in the meantime.
+	onGet func()
+}
+
+func (f *fakeLogs) AppendTransactionLog(context.Context, string, int, entity.LogEntry) error {
+	return nil
+}
+
+func (f *fakeLogs) GetTransaction(context.Context, string) (*entity.TransactionLog, error) {
+	if f.onGet != nil {
+		f.onGet()
+	}
+	return f.log, nil
+}
+
+// fakeOutbox records the events written to it.
+type fakeOutbox struct {
+	data.OutboxRepository
+	events []*entity.OutboxEvent
+}
+
+func (f *fakeOutbox) WithTx(*gorm.DB) data.OutboxRepository { return f }
+
+func (f *fakeOutbox) Create(_ context.Context, events ...*entity.OutboxEvent) error {
+	f.events = append(f.events, events...)
+	return nil
+}
@@ -0,0 +1,96 @@
+package biz
+
+import (
+	"bank-ledger/internal/kafka"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"bank-ledger/internal/entity"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// TransactionsTopic is the default topic carrying TransactionCommand
+	// messages to the consumer.
+	TransactionsTopic = "transactions"
+	// TransactionsDeadLetterTopic receives commands the consumer cannot decode.
+	TransactionsDeadLetterTopic = "transactions.dlq"
+
+	// TransactionCommandSchemaVersion is the schema version written by this build.
+	// Messages without a version header are legacy JSON commands.
+	TransactionCommandSchemaVersion = 1
+
+	transactionCommandSchema = "bankLedger.v1.TransactionCommand"
+	contentTypeProtobuf      = "application/x-protobuf"
+)
+
+// ErrUnsupportedSchemaVersion is returned for commands written by a newer or
+// unknown producer.
+var ErrUnsupportedSchemaVersion = errors.New("unsupported schema version")
+
+// EncodeTransactionCommand returns the record value and headers for cmd.
+func EncodeTransactionCommand(cmd *v1.TransactionCommand) ([]byte, []kafka.Header, error) {
+	value, err := proto.Marshal(cmd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal transaction command: %w", err)
+	}
+	return value, []kafka.Header{
+		{Key: kafka.HeaderContentType, Value: contentTypeProtobuf},
+		{Key: kafka.HeaderSchema, Value: transactionCommandSchema},
+		{Key: kafka.HeaderSchemaVersion, Value: strconv.Itoa(TransactionCommandSchemaVersion)},
+	}, nil
+}
+
+// NewTransactionCommand rebuilds the command that asks the consumer to apply trx.
+func NewTransactionCommand(trx *entity.Transaction) *v1.TransactionCommand {
+	return &v1.TransactionCommand{
+		TransactionId:         trx.ID,
+		AccountId:             trx.AccountID,
+		CounterpartyAccountId: trx.CounterpartyAccountID,
+		Amount:                trx.Amount,
+		Type:                  v1.TransactionType(v1.TransactionType_value[trx.Type]),
+		Description:           trx.Description,
+		Currency:              trx.Currency,
+		Status:                v1.TransactionStatus_INITIATED,
+		CreatedAt:             trx.CreatedAt.Format(time.RFC3339),
+		Sequence:              trx.Sequence,
+	}
+}
+
+// CommandKey returns the record key for cmd, which decides its partition.
+// Commands are keyed by the debited account, transfers included, so every
+// command that can lower an account's balance is applied in the order it was
+// issued. The credit side of a transfer may interleave with the counterparty's
+// own commands; the consumer's row locks keep those changes from being lost.
+func CommandKey(cmd *v1.TransactionCommand) string {
+	return cmd.AccountId
+}
+
+// DecodeTransactionCommand decodes a record produced by EncodeTransactionCommand
+// or by a build that still published JSON without headers.
+func DecodeTransactionCommand(value []byte, headers map[string]string) (*v1.TransactionCommand, error) {
+	cmd := &v1.TransactionCommand{}
+
+	version, ok := headers[kafka.HeaderSchemaVersion]
+	if !ok {
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(value, cmd); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal legacy transaction command: %w", err)
+		}
+		return cmd, nil
+	}
+
+	if version != strconv.Itoa(TransactionCommandSchemaVersion) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSchemaVersion, version)
+	}
+	if schema, ok := headers[kafka.HeaderSchema]; ok && schema != transactionCommandSchema {
+		return nil, fmt.Errorf("unexpected schema %s", schema)
+	}
+	if err := proto.Unmarshal(value, cmd); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction command: %w", err)
+	}
+	return cmd, nil
+}
@@ -0,0 +1,178 @@
+package biz
+
+import (
+	"bank-ledger/internal/kafka"
+	"errors"
+	"strings"
+	"testing"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeTransactionCommand(t *testing.T) {
+	cmd := &v1.TransactionCommand{
+		TransactionId: "trx-1",
+		AccountId:     "acc-1",
+		Amount:        25.5,
+		Type:          v1.TransactionType_DEPOSIT,
+		Status:        v1.TransactionStatus_INITIATED,
+		Sequence:      7,
+	}
+	value, headers, err := EncodeTransactionCommand(cmd)
+	if err != nil {
+		t.Fatalf("EncodeTransactionCommand: %v", err)
+	}
+	current := make(map[string]string, len(headers))
+	for _, h := range headers {
+		current[h.Key] = h.Value
+	}
+	withHeader := func(key, value string) map[string]string {
+		m := make(map[string]string, len(current))
+		for k, v := range current {
+			m[k] = v
+		}
+		m[key] = value
+		return m
+	}
+
+	tests := []struct {
+		name    string
+		value   []byte
+		headers map[string]string
+		want    *v1.TransactionCommand
+		wantErr error
+		errText string
+	}{
+		{
+			name:    "current version",
+			value:   value,
+			headers: current,
+			want:    cmd,
+		},
+		{
+			name:    "current version without schema header",
+			value:   value,
+			headers: map[string]string{kafka.HeaderSchemaVersion: current[kafka.HeaderSchemaVersion]},
+			want:    cmd,
+		},
+		{
+			name:    "legacy json",
+			value:   []byte(`{"transactionId":"trx-1","accountId":"acc-1","amount":25.5,"type":"DEPOSIT","status":"INITIATED","sequence":"7"}`),
+			headers: nil,
+			want:    cmd,
+		},
+		{
+			name:    "legacy json with unknown fields",
+			value:   []byte(`{"transaction_id":"trx-1","account_id":"acc-1","amount":25.5,"type":"DEPOSIT","status":"INITIATED","sequence":7,"retired":true}`),
+			headers: map[string]string{},
+			want:    cmd,
+		},
+		{
+			name:    "legacy garbage",
+			value:   []byte("not json"),
+			errText: "legacy transaction command",
+		},
+		{
+			name:    "newer version",
+			value:   value,
+			headers: withHeader(kafka.HeaderSchemaVersion, "2"),
+			wantErr: ErrUnsupportedSchemaVersion,
+		},
+		{
+			name:    "empty version",
+			value:   value,
+			headers: withHeader(kafka.HeaderSchemaVersion, ""),
+			wantErr: ErrUnsupportedSchemaVersion,
+		},
+		{
+			name:    "other schema",
+			value:   value,
+			headers: withHeader(kafka.HeaderSchema, "bankLedger.v1.LedgerEvent"),
+			errText: "unexpected schema",
+		},
+		{
+			name:    "corrupt protobuf",
+			value:   []byte{0xff, 0xff, 0xff},
+			headers: current,
+			errText: "failed to unmarshal transaction command",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeTransactionCommand(tt.value, tt.headers)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			case tt.errText != "":
+				if err == nil || !strings.Contains(err.Error(), tt.errText) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.errText)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Fatalf("command = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandKey(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  *v1.TransactionCommand
+		want string
+	}{
+		{
+			name: "deposit",
+			cmd:  &v1.TransactionCommand{AccountId: "acc-1", Type: v1.TransactionType_DEPOSIT},
+			want: "acc-1",
+		},
+		{
+			name: "withdrawal",
+			cmd:  &v1.TransactionCommand{AccountId: "acc-1", Type: v1.TransactionType_WITHDRAWAL},
+			want: "acc-1",
+		},
+		{
+			name: "transfer keyed by the debited account",
+			cmd:  &v1.TransactionCommand{AccountId: "acc-2", CounterpartyAccountId: "acc-1", Type: v1.TransactionType_TRANSFER},
+			want: "acc-2",
+		},
+		{
+			name: "fee",
+			cmd:  &v1.TransactionCommand{AccountId: "acc-3", Type: v1.TransactionType_FEE},
+			want: "acc-3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CommandKey(tt.cmd); got != tt.want {
+				t.Fatalf("CommandKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCommandKeyOrdersDebits checks that every command able to lower an
+// account's balance shares that account's key, whichever way a transfer runs.
+func TestCommandKeyOrdersDebits(t *testing.T) {
+	commands := []*v1.TransactionCommand{
+		{AccountId: "acc-1", Type: v1.TransactionType_WITHDRAWAL},
+		{AccountId: "acc-1", CounterpartyAccountId: "acc-2", Type: v1.TransactionType_TRANSFER},
+		{AccountId: "acc-1", CounterpartyAccountId: "acc-3", Type: v1.TransactionType_TRANSFER},
+		{AccountId: "acc-1", Type: v1.TransactionType_FEE},
+	}
+	for _, cmd := range commands {
+		if key := CommandKey(cmd); key != "acc-1" {
+			t.Errorf("%s to %q keyed %q, want acc-1", cmd.Type, cmd.CounterpartyAccountId, key)
+		}
+	}
+}
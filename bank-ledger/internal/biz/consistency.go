@@ -0,0 +1,183 @@
+package biz
+
+import (
+	"bank-ledger/internal/data"
+	"bank-ledger/internal/entity"
+	"context"
+	"fmt"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Kinds of LogDiscrepancy.
+const (
+	LogMissing        = "missing"
+	LogStatusMismatch = "status_mismatch"
+	LogOrphaned       = "orphaned"
+)
+
+// consistencyPageSize is how many transactions are compared at a time.
+const consistencyPageSize = 500
+
+// ConsistencyOptions selects what the log consistency check covers and fixes.
+type ConsistencyOptions struct {
+	// SettleTime skips transactions changed more recently, whose log may
+	// still be being written.
+	SettleTime time.Duration
+	// Orphans also scans MongoDB for log documents without a MySQL row.
+	Orphans bool
+	// Repair rewrites missing or divergent log documents from MySQL.
+	Repair bool
+}
+
+type ConsistencyChecker interface {
+	Check(ctx context.Context, opts ConsistencyOptions) (*v1.LogConsistencyReport, error)
+}
+
+type LogConsistency struct {
+	trx    data.TransactionRepository
+	trxLog data.TransactionLogsRepository
+	log    *log.Helper
+}
+
+func NewConsistencyChecker(trx data.TransactionRepository, trxLogs data.TransactionLogsRepository, logger log.Logger) ConsistencyChecker {
+	return &LogConsistency{
+		trx:    trx,
+		trxLog: trxLogs,
+		log:    log.NewHelper(log.With(logger, "module", "biz/consistency")),
+	}
+}
+
+// Check compares every MySQL transaction with its MongoDB log document by
+// transaction ID. MySQL is the source of truth: a log document is divergent
+// when its status differs from the row's.
+func (c *LogConsistency) Check(ctx context.Context, opts ConsistencyOptions) (*v1.LogConsistencyReport, error) {
+	report := &v1.LogConsistencyReport{StartedAt: time.Now().Format(time.RFC3339)}
+	before := time.Now().Add(-opts.SettleTime)
+
+	afterID := ""
+	for {
+		transactions, err := c.trx.FindLoggedAfter(ctx, afterID, before, consistencyPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list transactions: %w", err)
+		}
+		if len(transactions) == 0 {
+			break
+		}
+		afterID = transactions[len(transactions)-1].ID
+
+		ids := make([]string, 0, len(transactions))
+		for _, trx := range transactions {
+			ids = append(ids, trx.ID)
+		}
+		docs, err := c.trxLog.FindTransactions(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]*entity.TransactionLog, len(docs))
+		for _, doc := range docs {
+			byID[doc.TransactionID] = doc
+		}
+
+		for _, trx := range transactions {
+			report.TransactionsChecked++
+			doc, ok := byID[trx.ID]
+			switch {
+			case !ok:
+				report.Discrepancies = append(report.Discrepancies, c.resolve(ctx, trx, &v1.LogDiscrepancy{
+					TransactionId: trx.ID,
+					Kind:          LogMissing,
+					MysqlStatus:   trx.Status,
+				}, opts.Repair))
+			case doc.Status != trx.Status:
+				report.Discrepancies = append(report.Discrepancies, c.resolve(ctx, trx, &v1.LogDiscrepancy{
+					TransactionId: trx.ID,
+					Kind:          LogStatusMismatch,
+					MysqlStatus:   trx.Status,
+					MongoStatus:   doc.Status,
+				}, opts.Repair))
+			}
+		}
+
+		if len(transactions) < consistencyPageSize {
+			break
+		}
+	}
+
+	if opts.Orphans {
+		if err := c.findOrphans(ctx, report); err != nil {
+			return nil, err
+		}
+	}
+
+	report.FinishedAt = time.Now().Format(time.RFC3339)
+	return report, nil
+}
+
+// resolve logs a discrepancy and, when asked, repairs it by appending an entry
+// with the MySQL status, which also sets the document's status and creates the
+// document if it is missing.
+func (c *LogConsistency) resolve(ctx context.Context, trx *entity.Transaction, discrepancy *v1.LogDiscrepancy, repair bool) *v1.LogDiscrepancy {
+	c.log.Warnf("transaction %s log is %s: mysql %q, mongo %q", trx.ID, discrepancy.Kind, discrepancy.MysqlStatus, discrepancy.MongoStatus)
+	if !repair {
+		return discrepancy
+	}
+
+	attempt := trx.RetryCount
+	if attempt == 0 {
+		attempt = 1
+	}
+	err := c.trxLog.AppendTransactionLog(ctx, trx.ID, attempt, StampLogEntry(ctx, entity.LogEntry{
+		Timestamp: time.Now(),
+		Message:   fmt.Sprintf("Log repaired from MySQL: %s", trx.ProcessDescription),
+		Status:    trx.Status,
+	}))
+	if err != nil {
+		c.log.Errorf("failed to repair log of transaction %s: %v", trx.ID, err)
+		return discrepancy
+	}
+	discrepancy.Repaired = true
+	return discrepancy
+}
+
+// findOrphans reports log documents whose transaction has no MySQL row. They
+// cannot be repaired from MySQL and are left in place.
+func (c *LogConsistency) findOrphans(ctx context.Context, report *v1.LogConsistencyReport) error {
+	afterID := ""
+	for {
+		ids, err := c.trxLog.ListTransactionIDs(ctx, afterID, consistencyPageSize)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		afterID = ids[len(ids)-1]
+		report.LogDocumentsChecked += int32(len(ids))
+
+		transactions, err := c.trx.FindByIDs(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to find transactions: %w", err)
+		}
+		found := make(map[string]bool, len(transactions))
+		for _, trx := range transactions {
+			found[trx.ID] = true
+		}
+		for _, id := range ids {
+			if found[id] {
+				continue
+			}
+			c.log.Warnf("transaction log %s has no mysql row", id)
+			report.Discrepancies = append(report.Discrepancies, &v1.LogDiscrepancy{
+				TransactionId: id,
+				Kind:          LogOrphaned,
+			})
+		}
+
+		if len(ids) < consistencyPageSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package biz
+
+import (
+	"bank-ledger/internal/conf"
+	"bank-ledger/internal/data"
+	"bank-ledger/internal/entity"
+	"bank-ledger/internal/kafka"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/rs/xid"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+const (
+	// LedgerEventsTopic is the default name of the public, protobuf-encoded
+	// domain event stream.
+	LedgerEventsTopic = "ledger.events.v1"
+	// LedgerEventSchemaVersion is bumped only for incompatible envelope changes,
+	// which also move the stream to a new topic.
+	LedgerEventSchemaVersion = 1
+)
+
+const (
+	LedgerEventAccountOpened        = "AccountOpened"
+	LedgerEventAccountClosed        = "AccountClosed"
+	LedgerEventTransactionSucceeded = "TransactionSucceeded"
+	LedgerEventTransactionFailed    = "TransactionFailed"
+	LedgerEventBalanceChanged       = "BalanceChanged"
+)
+
+func AccountOpenedEvent(acc *entity.Account) *v1.LedgerEvent {
+	event := newLedgerEvent(LedgerEventAccountOpened, acc.ID)
+	event.Payload = &v1.LedgerEvent_AccountOpened{AccountOpened: &v1.AccountOpened{
+		AccountId:     acc.ID,
+		AccountNumber: acc.AccountNumber,
+		Name:          acc.Name,
+		Currency:      v1.Currency(v1.Currency_value[acc.Currency]),
+	}}
+	return event
+}
+
+func AccountClosedEvent(acc *entity.Account) *v1.LedgerEvent {
+	event := newLedgerEvent(LedgerEventAccountClosed, acc.ID)
+	event.Payload = &v1.LedgerEvent_AccountClosed{AccountClosed: &v1.AccountClosed{
+		AccountId:     acc.ID,
+		AccountNumber: acc.AccountNumber,
+		Balance:       acc.Balance,
+		Currency:      v1.Currency(v1.Currency_value[acc.Currency]),
+	}}
+	return event
+}
+
+func TransactionSucceededEvent(trx *entity.Transaction, fees []*entity.Transaction) *v1.LedgerEvent {
+	succeeded := &v1.TransactionSucceeded{Transaction: ToProtoTransaction(trx)}
+	for _, fee := range fees {
+		succeeded.Fees = append(succeeded.Fees, ToProtoTransaction(fee))
+	}
+
+	event := newLedgerEvent(LedgerEventTransactionSucceeded, trx.AccountID)
+	event.Payload = &v1.LedgerEvent_TransactionSucceeded{TransactionSucceeded: succeeded}
+	return event
+}
+
+func TransactionFailedEvent(trx *entity.Transaction, reason string) *v1.LedgerEvent {
+	event := newLedgerEvent(LedgerEventTransactionFailed, trx.AccountID)
+	event.Payload = &v1.LedgerEvent_TransactionFailed{TransactionFailed: &v1.TransactionFailed{
+		Transaction: ToProtoTransaction(trx),
+		Reason:      reason,
+	}}
+	return event
+}
+
+func BalanceChangedEvent(acc *entity.Account, previous float64, transactionID string) *v1.LedgerEvent {
+	event := newLedgerEvent(LedgerEventBalanceChanged, acc.ID)
+	event.Payload = &v1.LedgerEvent_BalanceChanged{BalanceChanged: &v1.BalanceChanged{
+		AccountId:       acc.ID,
+		PreviousBalance: previous,
+		Balance:         acc.Balance,
+		Delta:           roundAmount(acc.Balance - previous),
+		Currency:        acc.Currency,
+		TransactionId:   transactionID,
+	}}
+	return event
+}
+
+// WriteEvents stores events in the outbox. Pass a repository bound to the
+// transaction making the change so both commit or roll back together.
+func WriteEvents(ctx context.Context, outbox data.OutboxRepository, events ...*v1.LedgerEvent) error {
+	records := make([]*entity.OutboxEvent, 0, len(events))
+	for _, event := range events {
+		payload, err := proto.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s event: %w", event.Type, err)
+		}
+		records = append(records, &entity.OutboxEvent{
+			ID:        event.Id,
+			Topic:     LedgerEventsTopic,
+			Key:       event.AggregateId,
+			EventType: event.Type,
+			Payload:   payload,
+			CreatedAt: time.Now(),
+		})
+	}
+	return outbox.Create(ctx, records...)
+}
+
+func newLedgerEvent(eventType string, aggregateID string) *v1.LedgerEvent {
+	return &v1.LedgerEvent{
+		Id:            xid.New().String(),
+		Type:          eventType,
+		SchemaVersion: LedgerEventSchemaVersion,
+		OccurredAt:    time.Now().Format(time.RFC3339),
+		AggregateId:   aggregateID,
+	}
+}
+
+func ledgerEventHeaders(eventType string) []kafka.Header {
+	return []kafka.Header{
+		{Key: kafka.HeaderContentType, Value: contentTypeProtobuf},
+		{Key: kafka.HeaderSchema, Value: "bankLedger.v1.LedgerEvent"},
+		{Key: kafka.HeaderSchemaVersion, Value: strconv.Itoa(LedgerEventSchemaVersion)},
+		{Key: "event-type", Value: eventType},
+	}
+}
+
+type OutboxRelay interface {
+	PublishPending(ctx context.Context, limit int) (int, error)
+}
+
+type Outbox struct {
+	data      *data.Data
+	repo      data.OutboxRepository
+	producer  kafka.Producer
+	topics    *Topics
+	retention time.Duration
+	log       *log.Helper
+}
+
+func NewOutboxRelay(c *conf.Server, d *data.Data, repo data.OutboxRepository, producer kafka.Producer, topics *Topics, logger log.Logger) OutboxRelay {
+	o := &Outbox{
+		data:      d,
+		repo:      repo,
+		producer:  producer,
+		topics:    topics,
+		retention: 7 * 24 * time.Hour,
+		log:       log.NewHelper(log.With(logger, "module", "biz/outbox")),
+	}
+	if c.GetOutbox().GetRetention() != nil {
+		o.retention = c.GetOutbox().GetRetention().AsDuration()
+	}
+	return o
+}
+
+// PublishPending relays up to limit unpublished events in creation order and
+// deletes up to limit events published longer ago than the retention. Only
+// the replica holding the relay lock publishes, and it stops at the first
+// failure, so later events never overtake an earlier one.
+func (o *Outbox) PublishPending(ctx context.Context, limit int) (int, error) {
+	published := 0
+	err := o.data.DB().Transaction(func(tx *gorm.DB) error {
+		repo := o.repo.WithTx(tx)
+
+		leader, err := repo.LockRelay(ctx)
+		if err != nil {
+			return err
+		}
+		if !leader {
+			return nil
+		}
+		defer func() {
+			if err := repo.UnlockRelay(ctx); err != nil {
+				o.log.Errorf("failed to release outbox relay lock: %v", err)
+			}
+		}()
+
+		if pruned, err := repo.DeletePublishedBefore(ctx, time.Now().Add(-o.retention), limit); err != nil {
+			o.log.Errorf("failed to delete published outbox events: %v", err)
+		} else if pruned > 0 {
+			o.log.Infof("deleted %d published outbox events", pruned)
+		}
+
+		events, err := repo.LockPending(ctx, limit)
+		if err != nil {
+			return err
+		}
+
+		// Hand every event to the producer before waiting, so an async
+		// producer can batch them; only the prefix delivered before the
+		// first failure is marked published.
+		results := make([]error, len(events))
+		sent := len(events)
+		var wg sync.WaitGroup
+		for i, event := range events {
+			i := i
+			wg.Add(1)
+			if err := o.producer.SendMessageAsync(o.topics.Resolve(event.Topic), []byte(event.Key), event.Payload, func(err error) {
+				results[i] = err
+				wg.Done()
+			}, ledgerEventHeaders(event.EventType)...); err != nil {
+				results[i] = err
+				wg.Done()
+				sent = i + 1
+				break
+			}
+		}
+		wg.Wait()
+
+		var ids []string
+		var sendErr error
+		for i, event := range events[:sent] {
+			if sendErr = results[i]; sendErr != nil {
+				event.Attempts++
+				event.LastError = sendErr.Error()
+				if err := repo.Update(ctx, event); err != nil {
+					o.log.Errorf("failed to record outbox failure for %s: %v", event.ID, err)
+				}
+				break
+			}
+			ids = append(ids, event.ID)
+		}
+
+		if err := repo.MarkPublished(ctx, ids, time.Now()); err != nil {
+			return err
+		}
+		published = len(ids)
+		if sendErr != nil {
+			o.log.Errorf("failed to publish outbox events: %v", sendErr)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to relay outbox: %w", err)
+	}
+	return published, nil
+}
@@ -0,0 +1,135 @@
+package biz
+
+import (
+	"bank-ledger/internal/data"
+	"bank-ledger/internal/entity"
+	"bank-ledger/internal/kafka"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gorm.io/gorm"
+)
+
+// TransactionFailures fails transactions outside the consumer, recording and
+// announcing the failure the way the consumer does: the FAILED row and its
+// ledger event in one database transaction, then the transaction log, the
+// status event and the webhook.
+type TransactionFailures struct {
+	data     *data.Data
+	trx      data.TransactionRepository
+	trxLog   data.TransactionLogsRepository
+	outbox   data.OutboxRepository
+	producer kafka.Producer
+	topics   *Topics
+	webhooks WebhookHandler
+	log      *log.Helper
+
+	pending sync.WaitGroup
+}
+
+func NewTransactionFailures(d *data.Data, trx data.TransactionRepository, trxLogs data.TransactionLogsRepository, outbox data.OutboxRepository, producer kafka.Producer, topics *Topics, webhooks WebhookHandler, logger log.Logger) *TransactionFailures {
+	return &TransactionFailures{
+		data:     d,
+		trx:      trx,
+		trxLog:   trxLogs,
+		outbox:   outbox,
+		producer: producer,
+		topics:   topics,
+		webhooks: webhooks,
+		log:      log.NewHelper(log.With(logger, "module", "biz/failure")),
+	}
+}
+
+// Fail fails the transaction if it is still in status and reports whether it
+// did.
+func (f *TransactionFailures) Fail(ctx context.Context, transactionID, status, reason string) (bool, error) {
+	var failed *entity.Transaction
+	err := f.data.DB().Transaction(func(tx *gorm.DB) error {
+		trx, err := f.trx.WithTx(tx).FindByIDForUpdate(ctx, transactionID)
+		if err != nil {
+			return err
+		}
+		if trx.Status != status {
+			return nil
+		}
+		if err := f.markFailed(ctx, tx, trx, reason); err != nil {
+			return err
+		}
+		failed = trx
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to fail transaction %s: %w", transactionID, err)
+	}
+	if failed == nil {
+		return false, nil
+	}
+	f.notify(ctx, failed, reason)
+	return true, nil
+}
+
+// FailAsync runs Fail in the background, for callers that must not block such
+// as producer delivery callbacks. Wait waits for the runs it started.
+func (f *TransactionFailures) FailAsync(ctx context.Context, transactionID, status, reason string) {
+	f.pending.Add(1)
+	go func() {
+		defer f.pending.Done()
+		if _, err := f.Fail(ctx, transactionID, status, reason); err != nil {
+			f.log.Errorf("%v", err)
+		}
+	}()
+}
+
+// Wait blocks until every FailAsync run has finished. Call it after the
+// producer is closed, so the callbacks run by its final flush are included,
+// and before the databases are.
+func (f *TransactionFailures) Wait() {
+	f.pending.Wait()
+}
+
+// markFailed sets the locked transaction FAILED within tx and records its
+// ledger event. Call notify once tx commits.
+func (f *TransactionFailures) markFailed(ctx context.Context, tx *gorm.DB, trx *entity.Transaction, reason string) error {
+	trx.Status = v1.TransactionStatus_FAILED.String()
+	trx.ProcessDescription = reason
+	if err := f.trx.WithTx(tx).Update(ctx, trx); err != nil {
+		return err
+	}
+	if err := WriteEvents(ctx, f.outbox.WithTx(tx), TransactionFailedEvent(trx, reason)); err != nil {
+		return fmt.Errorf("failed to record ledger event: %w", err)
+	}
+	return nil
+}
+
+// notify logs a committed failure and tells watchers and webhook subscribers.
+// Failures here are logged; the transaction is already FAILED.
+func (f *TransactionFailures) notify(ctx context.Context, trx *entity.Transaction, reason string) {
+	attempt := trx.RetryCount
+	if attempt == 0 {
+		attempt = 1
+	}
+	entry := StampLogEntry(ctx, entity.LogEntry{
+		Message: reason,
+		Status:  trx.Status,
+	})
+	entry.Timestamp = time.Now()
+	if err := f.trxLog.AppendTransactionLog(ctx, trx.ID, attempt, entry); err != nil {
+		f.log.Errorf("failed to append transaction log for %s: %v", trx.ID, err)
+	}
+
+	payload, err := protojson.Marshal(NewTransactionEvent(trx, attempt, entry))
+	if err != nil {
+		f.log.Errorf("failed to marshal transaction event: %v", err)
+	} else if err := f.producer.SendMessage(f.topics.TransactionStatus, []byte(trx.ID), payload, kafka.ContextHeaders(ctx)...); err != nil {
+		f.log.Errorf("failed to publish transaction event: %v", err)
+	}
+
+	if err := f.webhooks.Enqueue(ctx, WebhookEventTransactionFailed, ToProtoTransaction(trx), trx.AccountID, trx.CounterpartyAccountID); err != nil {
+		f.log.Errorf("failed to enqueue %s webhook for transaction %s: %v", WebhookEventTransactionFailed, trx.ID, err)
+	}
+}
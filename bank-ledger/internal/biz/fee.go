@@ -0,0 +1,266 @@
+package biz
+
+import (
+	"bank-ledger/internal/conf"
+	"bank-ledger/internal/data"
+	"bank-ledger/internal/entity"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+)
+
+const (
+	FeeKindFlat       = "FLAT"
+	FeeKindPercentage = "PERCENTAGE"
+	FeeKindTiered     = "TIERED"
+
+	FeePeriodDaily   = "DAILY"
+	FeePeriodWeekly  = "WEEKLY"
+	FeePeriodMonthly = "MONTHLY"
+)
+
+// FeeLeg is a single fee charged on top of a transaction.
+type FeeLeg struct {
+	Rule   string
+	Amount float64
+}
+
+type FeeEngine interface {
+	Evaluate(txType string, amount float64) []FeeLeg
+	FeeTransactions(parent *entity.Transaction, legs []FeeLeg) []*entity.Transaction
+	ChargePeriodicFees(ctx context.Context, now time.Time) error
+}
+
+type Fee struct {
+	rules  []*conf.Fee_Rule
+	data   *data.Data
+	acc    data.AccountRepository
+	trx    data.TransactionRepository
+	outbox data.OutboxRepository
+	log    *log.Helper
+}
+
+func NewFeeEngine(c *conf.Fee, d *data.Data, acc data.AccountRepository, trx data.TransactionRepository, outbox data.OutboxRepository, logger log.Logger) FeeEngine {
+	var rules []*conf.Fee_Rule
+	if c != nil {
+		rules = c.Rules
+	}
+	return &Fee{
+		rules:  rules,
+		data:   d,
+		acc:    acc,
+		trx:    trx,
+		outbox: outbox,
+		log:    log.NewHelper(log.With(logger, "module", "biz/fee")),
+	}
+}
+
+func (f *Fee) Evaluate(txType string, amount float64) []FeeLeg {
+	var legs []FeeLeg
+	for _, rule := range f.rules {
+		if rule.Period != "" || rule.TransactionType != txType {
+			continue
+		}
+		if fee := computeFee(rule, amount); fee > 0 {
+			legs = append(legs, FeeLeg{Rule: rule.Name, Amount: fee})
+		}
+	}
+	return legs
+}
+
+func (f *Fee) FeeTransactions(parent *entity.Transaction, legs []FeeLeg) []*entity.Transaction {
+	now := time.Now()
+	feeTxs := make([]*entity.Transaction, 0, len(legs))
+	for _, leg := range legs {
+		feeTxs = append(feeTxs, &entity.Transaction{
+			ID:                  xid.New().String(),
+			AccountID:           parent.AccountID,
+			Amount:              leg.Amount,
+			Currency:            parent.Currency,
+			Type:                v1.TransactionType_FEE.String(),
+			Status:              v1.TransactionStatus_SUCCESS.String(),
+			Description:         fmt.Sprintf("%s fee for transaction %s", leg.Rule, parent.ID),
+			ProcessDescription:  "Fee charged",
+			ParentTransactionID: parent.ID,
+			FeeRule:             leg.Rule,
+			CreatedAt:           now,
+			UpdatedAt:           now,
+			ProcessedAt:         &now,
+		})
+	}
+	return feeTxs
+}
+
+func (f *Fee) ChargePeriodicFees(ctx context.Context, now time.Time) error {
+	var periodic []*conf.Fee_Rule
+	for _, rule := range f.rules {
+		if rule.Period != "" {
+			periodic = append(periodic, rule)
+		}
+	}
+	if len(periodic) == 0 {
+		return nil
+	}
+
+	accounts, err := f.acc.ListAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	for _, rule := range periodic {
+		since, err := periodStart(rule.Period, now)
+		if err != nil {
+			f.log.Errorf("skipping fee rule %s: %v", rule.Name, err)
+			continue
+		}
+		for _, acc := range accounts {
+			if acc.Status != v1.AccountStatus_ACTIVE.String() {
+				continue
+			}
+			charged, err := f.trx.CountFeesSince(ctx, acc.ID, rule.Name, since)
+			if err != nil {
+				return fmt.Errorf("failed to check fee %s for account %s: %w", rule.Name, acc.ID, err)
+			}
+			if charged > 0 {
+				continue
+			}
+			if err := f.chargePeriodicFee(ctx, rule, acc.ID, since, now); err != nil {
+				f.log.Errorf("failed to charge fee %s for account %s: %v", rule.Name, acc.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// chargePeriodicFee charges rule's fee for the period starting at since. Every
+// consumer replica runs the fee ticker, so the fee is counted again under the
+// account row lock, and its idempotency key keeps a second charge for the
+// period out even if that check is bypassed.
+func (f *Fee) chargePeriodicFee(ctx context.Context, rule *conf.Fee_Rule, accountID string, since time.Time, now time.Time) error {
+	return f.data.DB().Transaction(func(tx *gorm.DB) error {
+		accRepo := f.acc.WithTx(tx)
+		trxRepo := f.trx.WithTx(tx)
+		outboxRepo := f.outbox.WithTx(tx)
+
+		// The consumer changes balances concurrently; the row lock keeps the
+		// fee from overwriting a change applied in between.
+		account, err := accRepo.FindByIDForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		charged, err := trxRepo.CountFeesSince(ctx, account.ID, rule.Name, since)
+		if err != nil {
+			return err
+		}
+		if charged > 0 {
+			return nil
+		}
+
+		amount := computeFee(rule, account.Balance)
+		if amount <= 0 {
+			return nil
+		}
+
+		key := periodicFeeKey(rule.Name, account.ID, since)
+		feeTx := &entity.Transaction{
+			ID:                 xid.New().String(),
+			AccountID:          account.ID,
+			IdempotencyKey:     &key,
+			Amount:             amount,
+			Currency:           account.Currency,
+			Type:               v1.TransactionType_FEE.String(),
+			Description:        fmt.Sprintf("%s fee for period starting %s", rule.Name, since.Format("2006-01-02")),
+			FeeRule:            rule.Name,
+			Status:             v1.TransactionStatus_SUCCESS.String(),
+			ProcessDescription: "Fee charged",
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		}
+
+		if account.Balance < amount {
+			feeTx.Status = v1.TransactionStatus_FAILED.String()
+			feeTx.ProcessDescription = "insufficient balance for fee"
+			if err := trxRepo.Create(ctx, feeTx); err != nil {
+				return err
+			}
+			return WriteEvents(ctx, outboxRepo, TransactionFailedEvent(feeTx, feeTx.ProcessDescription))
+		}
+
+		feeTx.ProcessedAt = &now
+		previous := account.Balance
+		account.Balance -= amount
+		if err := accRepo.Update(ctx, account); err != nil {
+			return err
+		}
+		if err := trxRepo.Create(ctx, feeTx); err != nil {
+			return err
+		}
+		if err := WriteEvents(ctx, outboxRepo, TransactionSucceededEvent(feeTx, nil), BalanceChangedEvent(account, previous, feeTx.ID)); err != nil {
+			return err
+		}
+
+		f.log.Infof("charged %s fee of %.2f to account %s", rule.Name, amount, account.ID)
+		return nil
+	})
+}
+
+// periodicFeeKey is the idempotency key of a periodic fee, unique per account,
+// rule and period.
+func periodicFeeKey(rule, accountID string, since time.Time) string {
+	return fmt.Sprintf("fee:%s:%s:%d", rule, accountID, since.Unix())
+}
+
+func computeFee(rule *conf.Fee_Rule, amount float64) float64 {
+	var fee float64
+	switch rule.Kind {
+	case FeeKindFlat:
+		fee = rule.Flat
+	case FeeKindPercentage:
+		fee = amount * rule.Percentage / 100
+	case FeeKindTiered:
+		tiers := make([]*conf.Fee_Tier, len(rule.Tiers))
+		copy(tiers, rule.Tiers)
+		sort.SliceStable(tiers, func(i, j int) bool {
+			if tiers[i].UpTo == 0 {
+				return false
+			}
+			return tiers[j].UpTo == 0 || tiers[i].UpTo < tiers[j].UpTo
+		})
+		for _, tier := range tiers {
+			if tier.UpTo == 0 || amount <= tier.UpTo {
+				fee = tier.Flat + amount*tier.Percentage/100
+				break
+			}
+		}
+	}
+
+	if rule.Min > 0 && fee < rule.Min {
+		fee = rule.Min
+	}
+	if rule.Max > 0 && fee > rule.Max {
+		fee = rule.Max
+	}
+	return math.Round(fee*100) / 100
+}
+
+func periodStart(period string, now time.Time) (time.Time, error) {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch period {
+	case FeePeriodDaily:
+		return day, nil
+	case FeePeriodWeekly:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset), nil
+	case FeePeriodMonthly:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()), nil
+	default:
+		return time.Time{}, fmt.Errorf("unknown fee period: %s", period)
+	}
+}
@@ -0,0 +1,205 @@
+package biz
+
+import (
+	"bank-ledger/internal/conf"
+	"bank-ledger/internal/entity"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+func TestComputeFee(t *testing.T) {
+	tiers := []*conf.Fee_Tier{
+		{UpTo: 0, Percentage: 0.5},
+		{UpTo: 1000, Flat: 2, Percentage: 1},
+		{UpTo: 100, Flat: 1},
+	}
+
+	tests := []struct {
+		name   string
+		rule   *conf.Fee_Rule
+		amount float64
+		want   float64
+	}{
+		{name: "flat", rule: &conf.Fee_Rule{Kind: FeeKindFlat, Flat: 1.5}, amount: 500, want: 1.5},
+		{name: "percentage", rule: &conf.Fee_Rule{Kind: FeeKindPercentage, Percentage: 1.5}, amount: 200, want: 3},
+		{name: "percentage rounds to cents", rule: &conf.Fee_Rule{Kind: FeeKindPercentage, Percentage: 1}, amount: 12.345, want: 0.12},
+		{name: "percentage below min", rule: &conf.Fee_Rule{Kind: FeeKindPercentage, Percentage: 1, Min: 0.5}, amount: 10, want: 0.5},
+		{name: "percentage above max", rule: &conf.Fee_Rule{Kind: FeeKindPercentage, Percentage: 2, Max: 25}, amount: 5000, want: 25},
+		{name: "tier at its bound", rule: &conf.Fee_Rule{Kind: FeeKindTiered, Tiers: tiers}, amount: 100, want: 1},
+		{name: "middle tier", rule: &conf.Fee_Rule{Kind: FeeKindTiered, Tiers: tiers}, amount: 500, want: 7},
+		{name: "open-ended tier", rule: &conf.Fee_Rule{Kind: FeeKindTiered, Tiers: tiers}, amount: 2000, want: 10},
+		{name: "no matching tier", rule: &conf.Fee_Rule{Kind: FeeKindTiered, Tiers: []*conf.Fee_Tier{{UpTo: 100, Flat: 1}}}, amount: 500, want: 0},
+		{name: "unknown kind", rule: &conf.Fee_Rule{Kind: "OTHER", Flat: 3}, amount: 100, want: 0},
+		{name: "unknown kind still has a min", rule: &conf.Fee_Rule{Kind: "OTHER", Min: 1}, amount: 100, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computeFee(tt.rule, tt.amount); got != tt.want {
+				t.Fatalf("computeFee() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if tiers[0].UpTo != 0 || tiers[2].UpTo != 100 {
+		t.Fatal("computeFee reordered the configured tiers")
+	}
+}
+
+func TestFeeEvaluate(t *testing.T) {
+	engine := NewFeeEngine(&conf.Fee{Rules: []*conf.Fee_Rule{
+		{Name: "withdrawal", TransactionType: "WITHDRAWAL", Kind: FeeKindFlat, Flat: 1},
+		{Name: "withdrawal-fx", TransactionType: "WITHDRAWAL", Kind: FeeKindPercentage, Percentage: 1},
+		{Name: "transfer", TransactionType: "TRANSFER", Kind: FeeKindPercentage, Percentage: 0.1, Min: 0.25},
+		{Name: "maintenance", TransactionType: "WITHDRAWAL", Kind: FeeKindFlat, Flat: 5, Period: FeePeriodMonthly},
+		{Name: "free-deposit", TransactionType: "DEPOSIT", Kind: FeeKindFlat},
+	}}, nil, nil, nil, nil, log.DefaultLogger)
+
+	tests := []struct {
+		name   string
+		txType string
+		amount float64
+		want   []FeeLeg
+	}{
+		{name: "every matching rule", txType: "WITHDRAWAL", amount: 200, want: []FeeLeg{{Rule: "withdrawal", Amount: 1}, {Rule: "withdrawal-fx", Amount: 2}}},
+		{name: "min applies", txType: "TRANSFER", amount: 10, want: []FeeLeg{{Rule: "transfer", Amount: 0.25}}},
+		{name: "zero fees are skipped", txType: "DEPOSIT", amount: 100, want: nil},
+		{name: "no rules", txType: "FEE", amount: 100, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := engine.Evaluate(tt.txType, tt.amount); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Evaluate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPeriodStart(t *testing.T) {
+	// Thursday.
+	now := time.Date(2024, 2, 15, 13, 45, 0, 0, time.UTC)
+
+	tests := []struct {
+		period  string
+		now     time.Time
+		want    time.Time
+		wantErr bool
+	}{
+		{period: FeePeriodDaily, now: now, want: time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)},
+		{period: FeePeriodWeekly, now: now, want: time.Date(2024, 2, 12, 0, 0, 0, 0, time.UTC)},
+		{period: FeePeriodWeekly, now: time.Date(2024, 2, 18, 23, 0, 0, 0, time.UTC), want: time.Date(2024, 2, 12, 0, 0, 0, 0, time.UTC)},
+		{period: FeePeriodMonthly, now: now, want: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{period: "YEARLY", now: now, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			got, err := periodStart(tt.period, tt.now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("periodStart(%s) succeeded, want error", tt.period)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Fatalf("periodStart(%s, %v) = %v, want %v", tt.period, tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChargePeriodicFeesChargesOncePerPeriod(t *testing.T) {
+	// Thursday; the monthly period starts on February 1st.
+	now := time.Date(2024, 2, 15, 13, 45, 0, 0, time.UTC)
+	since := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	rule := &conf.Fee_Rule{Name: "maintenance", Kind: FeeKindFlat, Flat: 5, Period: FeePeriodMonthly}
+	fee := func(at time.Time) *entity.Transaction {
+		return &entity.Transaction{ID: "fee-" + at.Format("0102"), AccountID: "acc-1", FeeRule: "maintenance", Type: "FEE", Status: "SUCCESS", Amount: 5, CreatedAt: at}
+	}
+
+	tests := []struct {
+		name     string
+		balance  float64
+		existing []*entity.Transaction
+		// concurrent is committed by another replica while this one waits
+		// for the account lock, after its unlocked check saw nothing.
+		concurrent  *entity.Transaction
+		wantBalance float64
+		wantFees    int
+		wantStatus  string
+	}{
+		{name: "first charge of the period", balance: 100, wantBalance: 95, wantFees: 1, wantStatus: "SUCCESS"},
+		{name: "charged earlier in the period", balance: 100, existing: []*entity.Transaction{fee(since.Add(time.Hour))}, wantBalance: 100, wantFees: 1},
+		{name: "charged last period", balance: 100, existing: []*entity.Transaction{fee(since.Add(-time.Hour))}, wantBalance: 95, wantFees: 2, wantStatus: "SUCCESS"},
+		{name: "another replica charged while waiting for the lock", balance: 100, concurrent: fee(since.Add(time.Hour)), wantBalance: 100, wantFees: 1},
+		{name: "insufficient balance is recorded once", balance: 2, wantBalance: 2, wantFees: 1, wantStatus: "FAILED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts := newFakeAccounts(&entity.Account{ID: "acc-1", Balance: tt.balance, Currency: "USD", Status: "ACTIVE"})
+			transactions := &fakeTransactions{transactions: tt.existing}
+			outbox := &fakeOutbox{}
+			if tt.concurrent != nil {
+				accounts.onLock = func(string) {
+					transactions.transactions = append(transactions.transactions, tt.concurrent)
+					accounts.onLock = nil
+				}
+			}
+			engine := NewFeeEngine(&conf.Fee{Rules: []*conf.Fee_Rule{rule}}, newTestData(t), accounts, transactions, outbox, log.DefaultLogger)
+
+			// A second tick, as another replica or the next interval would run.
+			for i := 0; i < 2; i++ {
+				if err := engine.ChargePeriodicFees(context.Background(), now); err != nil {
+					t.Fatalf("ChargePeriodicFees: %v", err)
+				}
+			}
+
+			if got := accounts.accounts["acc-1"].Balance; got != tt.wantBalance {
+				t.Errorf("balance = %v, want %v", got, tt.wantBalance)
+			}
+			if got := len(transactions.transactions); got != tt.wantFees {
+				t.Fatalf("%d fee transactions, want %d", got, tt.wantFees)
+			}
+			if tt.wantStatus == "" {
+				return
+			}
+			charged := transactions.transactions[len(transactions.transactions)-1]
+			if charged.Status != tt.wantStatus {
+				t.Errorf("fee status = %s, want %s", charged.Status, tt.wantStatus)
+			}
+			if want := periodicFeeKey("maintenance", "acc-1", since); charged.IdempotencyKey == nil || *charged.IdempotencyKey != want {
+				t.Errorf("fee idempotency key = %v, want %s", charged.IdempotencyKey, want)
+			}
+		})
+	}
+}
+
+func TestChargePeriodicFeeRejectsDuplicatePeriod(t *testing.T) {
+	since := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	rule := &conf.Fee_Rule{Name: "maintenance", Kind: FeeKindFlat, Flat: 5, Period: FeePeriodMonthly}
+	key := periodicFeeKey("maintenance", "acc-1", since)
+	// A fee for the period that the count cannot see, such as one booked with
+	// an older created_at, must still be kept out by the unique key.
+	transactions := &fakeTransactions{transactions: []*entity.Transaction{{ID: "fee-1", AccountID: "acc-1", IdempotencyKey: &key}}}
+	accounts := newFakeAccounts(&entity.Account{ID: "acc-1", Balance: 100, Status: "ACTIVE"})
+	engine := NewFeeEngine(nil, newTestData(t), accounts, transactions, &fakeOutbox{}, log.DefaultLogger).(*Fee)
+
+	err := engine.chargePeriodicFee(context.Background(), rule, "acc-1", since, since.Add(time.Hour))
+	if !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Fatalf("error = %v, want a duplicate key error", err)
+	}
+	if len(transactions.transactions) != 1 {
+		t.Fatalf("%d transactions, want the existing one only", len(transactions.transactions))
+	}
+}
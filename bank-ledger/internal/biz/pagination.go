@@ -0,0 +1,61 @@
+package biz
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// pageToken is the decoded form of the opaque transaction cursor handed to clients.
+type pageToken struct {
+	CreatedAt int64  `json:"t"`
+	ID        string `json:"i"`
+}
+
+// accountPageToken carries the sort the cursor was issued for, so a token
+// cannot be replayed against a different order_by.
+type accountPageToken struct {
+	OrderBy string `json:"o"`
+	Value   string `json:"v"`
+	ID      string `json:"i"`
+}
+
+func encodePageToken(createdAt time.Time, id string) string {
+	return encodeToken(pageToken{CreatedAt: createdAt.UnixNano(), ID: id})
+}
+
+func decodePageToken(token string) (time.Time, string, bool) {
+	var pt pageToken
+	if !decodeToken(token, &pt) || pt.ID == "" {
+		return time.Time{}, "", false
+	}
+	return time.Unix(0, pt.CreatedAt), pt.ID, true
+}
+
+func encodeToken(v interface{}) string {
+	raw, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeToken(token string, v interface{}) bool {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(raw, v) == nil
+}
+
+func normalizePageSize(size int32) int {
+	if size <= 0 {
+		return defaultPageSize
+	}
+	if size > maxPageSize {
+		return maxPageSize
+	}
+	return int(size)
+}
@@ -0,0 +1,88 @@
+package biz
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		id        string
+	}{
+		{name: "nanoseconds kept", createdAt: time.Date(2024, 3, 1, 12, 30, 45, 123456789, time.UTC), id: "cn1a2b3c4d5e6f7g8h9i"},
+		{name: "unix epoch", createdAt: time.Unix(0, 0), id: "x"},
+		{name: "id with url characters", createdAt: time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC), id: "a/b+c=d?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := encodePageToken(tt.createdAt, tt.id)
+			if _, err := base64.RawURLEncoding.DecodeString(token); err != nil {
+				t.Fatalf("token %q is not unpadded url-safe base64: %v", token, err)
+			}
+
+			createdAt, id, ok := decodePageToken(token)
+			if !ok {
+				t.Fatalf("decodePageToken(%q) failed", token)
+			}
+			if !createdAt.Equal(tt.createdAt) || id != tt.id {
+				t.Fatalf("decoded (%v, %q), want (%v, %q)", createdAt, id, tt.createdAt, tt.id)
+			}
+		})
+	}
+}
+
+func TestDecodePageTokenInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "not base64", token: "!!!"},
+		{name: "padded base64", token: base64.URLEncoding.EncodeToString([]byte(`{"t":1,"i":"ab"}`))},
+		{name: "not json", token: base64.RawURLEncoding.EncodeToString([]byte("cursor"))},
+		{name: "missing id", token: base64.RawURLEncoding.EncodeToString([]byte(`{"t":1}`))},
+		{name: "wrong field type", token: base64.RawURLEncoding.EncodeToString([]byte(`{"t":"yesterday","i":"a"}`))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, ok := decodePageToken(tt.token); ok {
+				t.Fatalf("decodePageToken(%q) succeeded, want failure", tt.token)
+			}
+		})
+	}
+}
+
+func TestAccountPageTokenRoundTrip(t *testing.T) {
+	want := accountPageToken{OrderBy: "balance desc", Value: "1050.25", ID: "acc-1"}
+
+	var got accountPageToken
+	if !decodeToken(encodeToken(want), &got) {
+		t.Fatal("decodeToken failed")
+	}
+	if got != want {
+		t.Fatalf("decoded %+v, want %+v", got, want)
+	}
+}
+
+func TestNormalizePageSize(t *testing.T) {
+	tests := []struct {
+		size int32
+		want int
+	}{
+		{size: -1, want: defaultPageSize},
+		{size: 0, want: defaultPageSize},
+		{size: 1, want: 1},
+		{size: maxPageSize, want: maxPageSize},
+		{size: maxPageSize + 1, want: maxPageSize},
+	}
+
+	for _, tt := range tests {
+		if got := normalizePageSize(tt.size); got != tt.want {
+			t.Errorf("normalizePageSize(%d) = %d, want %d", tt.size, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package biz
+
+import (
+	"bank-ledger/internal/data"
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+// reconcilePageSize is how many accounts are loaded at a time.
+const reconcilePageSize = 500
+
+// ReconcileOptions selects what reconciliation does beyond reporting.
+type ReconcileOptions struct {
+	// Freeze sets mismatched ACTIVE accounts to FROZEN.
+	Freeze bool
+	// Alert sends an account.balance_mismatch webhook per discrepancy.
+	Alert bool
+}
+
+type Reconciler interface {
+	Reconcile(ctx context.Context, opts ReconcileOptions) (*v1.ReconciliationReport, error)
+}
+
+type Reconciliation struct {
+	data     *data.Data
+	acc      data.AccountRepository
+	trx      data.TransactionRepository
+	webhooks WebhookHandler
+	log      *log.Helper
+}
+
+func NewReconciler(d *data.Data, acc data.AccountRepository, trx data.TransactionRepository, webhooks WebhookHandler, logger log.Logger) Reconciler {
+	return &Reconciliation{
+		data:     d,
+		acc:      acc,
+		trx:      trx,
+		webhooks: webhooks,
+		log:      log.NewHelper(log.With(logger, "module", "biz/reconcile")),
+	}
+}
+
+// Reconcile recomputes every account's balance from its SUCCESS transactions
+// and reports the accounts whose stored balance differs.
+func (r *Reconciliation) Reconcile(ctx context.Context, opts ReconcileOptions) (*v1.ReconciliationReport, error) {
+	report := &v1.ReconciliationReport{StartedAt: time.Now().Format(time.RFC3339)}
+
+	var after *data.AccountCursor
+	for {
+		accounts, err := r.acc.FindPage(ctx, data.AccountFilter{}, data.AccountSort{Field: "created_at"}, after, reconcilePageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list accounts: %w", err)
+		}
+
+		for _, acc := range accounts {
+			discrepancy, err := r.check(ctx, acc.ID, opts.Freeze)
+			if err != nil {
+				return nil, fmt.Errorf("failed to reconcile account %s: %w", acc.ID, err)
+			}
+			report.AccountsChecked++
+			if discrepancy == nil {
+				continue
+			}
+
+			report.Discrepancies = append(report.Discrepancies, discrepancy)
+			r.log.Errorf("balance mismatch on account %s: recorded %s, expected %s", acc.ID, discrepancy.Account.Balance, discrepancy.ExpectedBalance)
+			if opts.Alert {
+				if err := r.webhooks.Enqueue(ctx, WebhookEventBalanceMismatch, discrepancy, acc.ID); err != nil {
+					r.log.Errorf("failed to enqueue %s webhook for account %s: %v", WebhookEventBalanceMismatch, acc.ID, err)
+				}
+			}
+		}
+
+		if len(accounts) < reconcilePageSize {
+			break
+		}
+		last := accounts[len(accounts)-1]
+		after = &data.AccountCursor{Value: last.CreatedAt, ID: last.ID}
+	}
+
+	report.FinishedAt = time.Now().Format(time.RFC3339)
+	return report, nil
+}
+
+// check compares one account's balance with its transactions. Both are read in
+// a single database transaction, so they come from the same snapshot even while
+// the consumer keeps applying transactions.
+func (r *Reconciliation) check(ctx context.Context, accountID string, freeze bool) (*v1.BalanceDiscrepancy, error) {
+	var discrepancy *v1.BalanceDiscrepancy
+	err := r.data.DB().Transaction(func(tx *gorm.DB) error {
+		accounts := r.acc.WithTx(tx)
+
+		acc, err := accounts.FindByID(ctx, &v1.BaseRequest{Id: accountID})
+		if err != nil {
+			return err
+		}
+		expected, err := r.trx.WithTx(tx).SumSuccess(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		// Balances are stored with two decimals.
+		difference := math.Round((acc.Balance-expected)*100) / 100
+		if difference == 0 {
+			return nil
+		}
+
+		discrepancy = &v1.BalanceDiscrepancy{
+			Account:         toProtoAccount(acc),
+			ExpectedBalance: formatFloat(expected),
+			Difference:      formatFloat(difference),
+			CheckedAt:       time.Now().Format(time.RFC3339),
+		}
+		if !freeze || acc.Status != v1.AccountStatus_ACTIVE.String() {
+			return nil
+		}
+		if err := accounts.UpdateStatus(ctx, accountID, v1.AccountStatus_FROZEN.String()); err != nil {
+			return fmt.Errorf("failed to freeze account: %w", err)
+		}
+		discrepancy.Frozen = true
+		discrepancy.Account.Status = v1.AccountStatus_FROZEN
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return discrepancy, nil
+}
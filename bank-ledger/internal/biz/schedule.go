@@ -0,0 +1,308 @@
+package biz
+
+import (
+	"bank-ledger/internal/data"
+	"bank-ledger/internal/entity"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/rs/xid"
+)
+
+type ScheduleHandler interface {
+	Create(ctx context.Context, req *v1.CreateScheduleRequest) (*v1.ScheduleResponse, error)
+	FindByID(ctx context.Context, req *v1.BaseRequest) (*v1.ScheduleResponse, error)
+	ListByAccount(ctx context.Context, req *v1.ListSchedulesRequest) (*v1.ListSchedulesResponse, error)
+	Pause(ctx context.Context, req *v1.BaseRequest) (*v1.ScheduleResponse, error)
+	Resume(ctx context.Context, req *v1.BaseRequest) (*v1.ScheduleResponse, error)
+	Cancel(ctx context.Context, req *v1.BaseRequest) (*v1.ScheduleResponse, error)
+	RunDue(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+type Schedule struct {
+	repo data.ScheduleRepository
+	acc  data.AccountRepository
+	trx  TransactionHandler
+	log  *log.Helper
+}
+
+func NewScheduleHandler(repo data.ScheduleRepository, acc data.AccountRepository, trx TransactionHandler, logger log.Logger) ScheduleHandler {
+	return &Schedule{
+		repo: repo,
+		acc:  acc,
+		trx:  trx,
+		log:  log.NewHelper(log.With(logger, "module", "biz/schedule")),
+	}
+}
+
+func (s *Schedule) Create(ctx context.Context, req *v1.CreateScheduleRequest) (*v1.ScheduleResponse, error) {
+	if req.AccountId == "" {
+		return nil, errors.BadRequest("ACCOUNT_ID_REQUIRED", "account_id is required")
+	}
+
+	if req.Amount <= 0 {
+		return nil, errors.BadRequest("INVALID_AMOUNT", "amount must be positive")
+	}
+
+	switch req.Type {
+	case v1.TransactionType_DEPOSIT, v1.TransactionType_WITHDRAWAL:
+	case v1.TransactionType_TRANSFER:
+		if req.CounterpartyAccountId == "" || req.CounterpartyAccountId == req.AccountId {
+			return nil, errors.BadRequest("INVALID_COUNTERPARTY", "a different counterparty_account_id is required for transfers")
+		}
+	default:
+		return nil, errors.BadRequest("INVALID_TYPE", "only deposits, withdrawals and transfers can be scheduled")
+	}
+
+	if req.Frequency == v1.ScheduleFrequency_SCHEDULE_FREQUENCY_UNSPECIFIED {
+		return nil, errors.BadRequest("FREQUENCY_REQUIRED", "frequency is required")
+	}
+
+	acc, err := s.acc.FindByID(ctx, &v1.BaseRequest{Id: req.AccountId})
+	if err != nil {
+		return nil, errors.New(http.StatusNotFound, http.StatusText(http.StatusNotFound), "account does not exist")
+	}
+
+	if acc.Status == v1.AccountStatus_CLOSED.String() {
+		return nil, errors.New(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "account is closed")
+	}
+
+	startAt := time.Now()
+	if req.StartAt != "" {
+		startAt, err = time.Parse(time.RFC3339, req.StartAt)
+		if err != nil {
+			return nil, errors.BadRequest("INVALID_START_AT", "start_at must be an RFC3339 timestamp")
+		}
+	}
+
+	var endAt *time.Time
+	if req.EndAt != "" {
+		end, err := time.Parse(time.RFC3339, req.EndAt)
+		if err != nil {
+			return nil, errors.BadRequest("INVALID_END_AT", "end_at must be an RFC3339 timestamp")
+		}
+		if end.Before(startAt) {
+			return nil, errors.BadRequest("INVALID_END_AT", "end_at must not be before start_at")
+		}
+		endAt = &end
+	}
+
+	schedule := &entity.Schedule{
+		ID:                    xid.New().String(),
+		AccountID:             req.AccountId,
+		CounterpartyAccountID: req.CounterpartyAccountId,
+		Amount:                req.Amount,
+		Type:                  req.Type.String(),
+		Description:           req.Description,
+		Frequency:             req.Frequency.String(),
+		Status:                v1.ScheduleStatus_SCHEDULED.String(),
+		StartAt:               startAt,
+		EndAt:                 endAt,
+		NextRunAt:             &startAt,
+	}
+
+	if err := s.repo.Create(ctx, schedule); err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+
+	return toProtoSchedule(schedule), nil
+}
+
+func (s *Schedule) FindByID(ctx context.Context, req *v1.BaseRequest) (*v1.ScheduleResponse, error) {
+	schedule, err := s.repo.FindByID(ctx, req)
+	if err != nil {
+		return nil, errors.NotFound("SCHEDULE_NOT_FOUND", "schedule not found")
+	}
+	return toProtoSchedule(schedule), nil
+}
+
+func (s *Schedule) ListByAccount(ctx context.Context, req *v1.ListSchedulesRequest) (*v1.ListSchedulesResponse, error) {
+	if req.AccountId == "" {
+		return nil, errors.BadRequest("ACCOUNT_ID_REQUIRED", "account_id is required")
+	}
+
+	schedules, err := s.repo.ListByAccountID(ctx, req.AccountId)
+	if err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+
+	resp := make([]*v1.ScheduleResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		resp = append(resp, toProtoSchedule(schedule))
+	}
+	return &v1.ListSchedulesResponse{Schedules: resp}, nil
+}
+
+func (s *Schedule) Pause(ctx context.Context, req *v1.BaseRequest) (*v1.ScheduleResponse, error) {
+	return s.transition(ctx, req, v1.ScheduleStatus_SCHEDULED, v1.ScheduleStatus_PAUSED)
+}
+
+func (s *Schedule) Resume(ctx context.Context, req *v1.BaseRequest) (*v1.ScheduleResponse, error) {
+	return s.transition(ctx, req, v1.ScheduleStatus_PAUSED, v1.ScheduleStatus_SCHEDULED)
+}
+
+func (s *Schedule) Cancel(ctx context.Context, req *v1.BaseRequest) (*v1.ScheduleResponse, error) {
+	schedule, err := s.repo.FindByID(ctx, req)
+	if err != nil {
+		return nil, errors.NotFound("SCHEDULE_NOT_FOUND", "schedule not found")
+	}
+
+	if schedule.Status == v1.ScheduleStatus_CANCELLED.String() || schedule.Status == v1.ScheduleStatus_COMPLETED.String() {
+		return nil, errors.BadRequest("INVALID_SCHEDULE_STATUS", fmt.Sprintf("schedule is already %s", schedule.Status))
+	}
+
+	schedule.Status = v1.ScheduleStatus_CANCELLED.String()
+	schedule.NextRunAt = nil
+	if err := s.repo.Update(ctx, schedule); err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+	return toProtoSchedule(schedule), nil
+}
+
+func (s *Schedule) transition(ctx context.Context, req *v1.BaseRequest, from, to v1.ScheduleStatus) (*v1.ScheduleResponse, error) {
+	schedule, err := s.repo.FindByID(ctx, req)
+	if err != nil {
+		return nil, errors.NotFound("SCHEDULE_NOT_FOUND", "schedule not found")
+	}
+
+	if schedule.Status != from.String() {
+		return nil, errors.BadRequest("INVALID_SCHEDULE_STATUS", fmt.Sprintf("schedule is %s, expected %s", schedule.Status, from))
+	}
+
+	schedule.Status = to.String()
+	if to == v1.ScheduleStatus_SCHEDULED {
+		skipMissedOccurrences(schedule, time.Now())
+	}
+	if err := s.repo.Update(ctx, schedule); err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+	return toProtoSchedule(schedule), nil
+}
+
+// RunDue enqueues every occurrence that is due at now and returns how many were processed.
+func (s *Schedule) RunDue(ctx context.Context, now time.Time, limit int) (int, error) {
+	schedules, err := s.repo.FindDue(ctx, now, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find due schedules: %w", err)
+	}
+
+	processed := 0
+	for _, schedule := range schedules {
+		if err := s.runOccurrence(ctx, schedule); err != nil {
+			s.log.Errorf("failed to run schedule %s: %v", schedule.ID, err)
+			continue
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+func (s *Schedule) runOccurrence(ctx context.Context, schedule *entity.Schedule) error {
+	occurrence := *schedule.NextRunAt
+	resp, err := s.trx.Create(ctx, &v1.CreateTransactionRequest{
+		AccountId:             schedule.AccountID,
+		CounterpartyAccountId: schedule.CounterpartyAccountID,
+		Amount:                schedule.Amount,
+		Type:                  v1.TransactionType(v1.TransactionType_value[schedule.Type]),
+		Description:           schedule.Description,
+		IdempotencyKey:        fmt.Sprintf("schedule:%s:%d", schedule.ID, occurrence.Unix()),
+	})
+	if err != nil {
+		// Server-side failures are retried on the next tick; the idempotency key
+		// keeps the occurrence from being enqueued twice.
+		if errors.FromError(err).Code >= http.StatusInternalServerError {
+			return err
+		}
+		schedule.LastError = err.Error()
+	} else {
+		schedule.LastTransactionID = resp.TransactionId
+		schedule.LastError = ""
+	}
+
+	schedule.Occurrences++
+	next := nextOccurrence(schedule.Frequency, schedule.StartAt, schedule.Occurrences)
+	if next == nil || (schedule.EndAt != nil && next.After(*schedule.EndAt)) {
+		schedule.Status = v1.ScheduleStatus_COMPLETED.String()
+		schedule.NextRunAt = nil
+	} else {
+		schedule.NextRunAt = next
+	}
+
+	advanced, err := s.repo.AdvanceOccurrence(ctx, schedule, occurrence)
+	if err != nil {
+		return err
+	}
+	if !advanced {
+		s.log.Warnf("schedule %s changed while running its %s occurrence, keeping the change", schedule.ID, occurrence.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// skipMissedOccurrences moves a resumed schedule past the occurrences that fell
+// due while it was paused, so they are not all enqueued at once.
+func skipMissedOccurrences(schedule *entity.Schedule, now time.Time) {
+	for schedule.NextRunAt != nil && schedule.NextRunAt.Before(now) {
+		schedule.Occurrences++
+		next := nextOccurrence(schedule.Frequency, schedule.StartAt, schedule.Occurrences)
+		if next == nil || (schedule.EndAt != nil && next.After(*schedule.EndAt)) {
+			schedule.Status = v1.ScheduleStatus_COMPLETED.String()
+			schedule.NextRunAt = nil
+			return
+		}
+		schedule.NextRunAt = next
+	}
+}
+
+func nextOccurrence(frequency string, start time.Time, n int) *time.Time {
+	var next time.Time
+	switch frequency {
+	case v1.ScheduleFrequency_DAILY.String():
+		next = start.AddDate(0, 0, n)
+	case v1.ScheduleFrequency_WEEKLY.String():
+		next = start.AddDate(0, 0, 7*n)
+	case v1.ScheduleFrequency_MONTHLY.String():
+		// Clamp to the last day of the month so a schedule starting on the 31st
+		// runs at the end of shorter months instead of spilling into the next one.
+		firstOfMonth := time.Date(start.Year(), start.Month()+time.Month(n), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+		lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+		day := start.Day()
+		if day > lastDay {
+			day = lastDay
+		}
+		next = firstOfMonth.AddDate(0, 0, day-1)
+	default:
+		return nil
+	}
+	return &next
+}
+
+func toProtoSchedule(schedule *entity.Schedule) *v1.ScheduleResponse {
+	resp := &v1.ScheduleResponse{
+		Id:                    schedule.ID,
+		AccountId:             schedule.AccountID,
+		CounterpartyAccountId: schedule.CounterpartyAccountID,
+		Amount:                schedule.Amount,
+		Type:                  v1.TransactionType(v1.TransactionType_value[schedule.Type]),
+		Description:           schedule.Description,
+		Frequency:             v1.ScheduleFrequency(v1.ScheduleFrequency_value[schedule.Frequency]),
+		Status:                v1.ScheduleStatus(v1.ScheduleStatus_value[schedule.Status]),
+		StartAt:               schedule.StartAt.Format(time.RFC3339),
+		Occurrences:           int32(schedule.Occurrences),
+		LastTransactionId:     schedule.LastTransactionID,
+		LastError:             schedule.LastError,
+		CreatedAt:             schedule.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:             schedule.UpdatedAt.Format(time.RFC3339),
+	}
+	if schedule.EndAt != nil {
+		resp.EndAt = schedule.EndAt.Format(time.RFC3339)
+	}
+	if schedule.NextRunAt != nil {
+		resp.NextRunAt = schedule.NextRunAt.Format(time.RFC3339)
+	}
+	return resp
+}
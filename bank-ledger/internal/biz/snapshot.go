@@ -0,0 +1,168 @@
+package biz
+
+import (
+	"bank-ledger/internal/conf"
+	"bank-ledger/internal/data"
+	"bank-ledger/internal/entity"
+	"context"
+	"fmt"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type SnapshotHandler interface {
+	TakeDue(ctx context.Context, now time.Time, limit int) (int, error)
+	GetBalance(ctx context.Context, req *v1.GetBalanceRequest) (*v1.BalanceResponse, error)
+}
+
+type Snapshot struct {
+	repo        data.SnapshotRepository
+	acc         data.AccountRepository
+	trx         data.TransactionRepository
+	settleAfter time.Duration
+	log         *log.Helper
+}
+
+func NewSnapshotHandler(c *conf.Server, repo data.SnapshotRepository, acc data.AccountRepository, trx data.TransactionRepository, logger log.Logger) SnapshotHandler {
+	s := &Snapshot{
+		repo:        repo,
+		acc:         acc,
+		trx:         trx,
+		settleAfter: time.Hour,
+		log:         log.NewHelper(log.With(logger, "module", "biz/snapshot")),
+	}
+	if c != nil && c.Snapshot != nil && c.Snapshot.SettleAfter != nil {
+		s.settleAfter = c.Snapshot.SettleAfter.AsDuration()
+	}
+	return s
+}
+
+// TakeDue snapshots up to limit accounts missing the end-of-day snapshot of the
+// last settled day, filling any days missed since their previous snapshot, and
+// returns how many accounts were processed.
+func (s *Snapshot) TakeDue(ctx context.Context, now time.Time, limit int) (int, error) {
+	day := startOfDay(now.Add(-s.settleAfter)).AddDate(0, 0, -1)
+
+	accounts, err := s.repo.FindDueAccounts(ctx, day, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find accounts due a snapshot: %w", err)
+	}
+
+	processed := 0
+	for _, acc := range accounts {
+		if err := s.take(ctx, acc.ID, day); err != nil {
+			s.log.Errorf("failed to snapshot account %s for %s: %v", acc.ID, day.Format(statementDateLayout), err)
+			continue
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// take buckets transactions by the day they settled rather than the day they
+// were created, so one still processing when an earlier day was snapshotted
+// lands in a later day instead of being left out of the chain.
+func (s *Snapshot) take(ctx context.Context, accountID string, day time.Time) error {
+	previous, err := s.repo.FindLatest(ctx, accountID, day.AddDate(0, 0, -1))
+	if err != nil {
+		return err
+	}
+
+	first := day
+	var balance float64
+	if previous != nil {
+		first = startOfDay(previous.Day).AddDate(0, 0, 1)
+		balance = previous.ClosingBalance
+	} else if balance, err = s.trx.SumSettledBefore(ctx, accountID, day); err != nil {
+		return err
+	}
+
+	var snapshots []*entity.BalanceSnapshot
+	for d := first; !d.After(day); d = d.AddDate(0, 0, 1) {
+		summary, err := s.trx.SummarizeSettled(ctx, accountID, d, d.AddDate(0, 0, 1))
+		if err != nil {
+			return err
+		}
+		balance = roundAmount(balance + summary.Credits - summary.Debits)
+		snapshots = append(snapshots, &entity.BalanceSnapshot{
+			AccountID:      accountID,
+			Day:            d,
+			ClosingBalance: balance,
+			Credits:        summary.Credits,
+			Debits:         summary.Debits,
+			CreditCount:    summary.CreditCount,
+			DebitCount:     summary.DebitCount,
+		})
+	}
+	return s.repo.Save(ctx, snapshots...)
+}
+
+// GetBalance returns the account's balance at as_of: the closing balance of the
+// last snapshot before it plus the SUCCESS transactions settled since. Without as_of it
+// returns the current balance.
+func (s *Snapshot) GetBalance(ctx context.Context, req *v1.GetBalanceRequest) (*v1.BalanceResponse, error) {
+	if req.Id == "" {
+		return nil, errors.BadRequest("ACCOUNT_ID_REQUIRED", "id is required")
+	}
+
+	now := time.Now()
+	asOf := now
+	if req.AsOf != "" {
+		var err error
+		if asOf, err = parseStatementTime(req.AsOf, true); err != nil {
+			return nil, errors.BadRequest("INVALID_AS_OF", "as_of must be an RFC3339 timestamp or YYYY-MM-DD date")
+		}
+		if asOf.After(now) {
+			return nil, errors.BadRequest("INVALID_AS_OF", "as_of must not be in the future")
+		}
+	}
+
+	acc, err := s.acc.FindByID(ctx, &v1.BaseRequest{Id: req.Id})
+	if err != nil {
+		return nil, errors.NotFound("ACCOUNT_NOT_FOUND", "account not found")
+	}
+
+	resp := &v1.BalanceResponse{
+		AccountId: acc.ID,
+		Currency:  v1.Currency(v1.Currency_value[acc.Currency]),
+		AsOf:      asOf.Format(time.RFC3339),
+	}
+	if req.AsOf == "" {
+		resp.Balance = formatFloat(acc.Balance)
+		return resp, nil
+	}
+
+	// A snapshot applies when its day closed at or before as_of.
+	snapshot, err := s.repo.FindLatest(ctx, acc.ID, asOf.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+
+	var balance float64
+	if snapshot == nil {
+		if balance, err = s.trx.SumSettledBefore(ctx, acc.ID, asOf); err != nil {
+			return nil, errors.InternalServer("DB_ERROR", err.Error())
+		}
+	} else {
+		day := startOfDay(snapshot.Day)
+		delta, err := s.trx.SummarizeSettled(ctx, acc.ID, day.AddDate(0, 0, 1), asOf)
+		if err != nil {
+			return nil, errors.InternalServer("DB_ERROR", err.Error())
+		}
+		balance = snapshot.ClosingBalance + delta.Credits - delta.Debits
+		resp.SnapshotDate = day.Format(statementDateLayout)
+	}
+
+	resp.Balance = formatFloat(roundAmount(balance))
+	return resp, nil
+}
+
+// startOfDay returns local midnight of t's day, the boundary snapshots and
+// plain statement dates use.
+func startOfDay(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,158 @@
+package biz
+
+import (
+	"bank-ledger/internal/data"
+	"bank-ledger/internal/entity"
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+// fakeSnapshots keeps snapshots in memory, keyed by account and day.
+type fakeSnapshots struct {
+	data.SnapshotRepository
+	accounts  *fakeAccounts
+	snapshots map[string]*entity.BalanceSnapshot
+}
+
+func newFakeSnapshots(accounts *fakeAccounts) *fakeSnapshots {
+	return &fakeSnapshots{accounts: accounts, snapshots: make(map[string]*entity.BalanceSnapshot)}
+}
+
+func snapshotKey(accountID string, day time.Time) string {
+	return accountID + "/" + day.Format(statementDateLayout)
+}
+
+func (f *fakeSnapshots) WithTx(*gorm.DB) data.SnapshotRepository { return f }
+
+func (f *fakeSnapshots) Save(_ context.Context, snapshots ...*entity.BalanceSnapshot) error {
+	for _, snapshot := range snapshots {
+		copied := *snapshot
+		f.snapshots[snapshotKey(snapshot.AccountID, snapshot.Day)] = &copied
+	}
+	return nil
+}
+
+func (f *fakeSnapshots) FindLatest(_ context.Context, accountID string, onOrBefore time.Time) (*entity.BalanceSnapshot, error) {
+	var latest *entity.BalanceSnapshot
+	for _, snapshot := range f.snapshots {
+		if snapshot.AccountID != accountID || snapshot.Day.After(onOrBefore) {
+			continue
+		}
+		if latest == nil || snapshot.Day.After(latest.Day) {
+			latest = snapshot
+		}
+	}
+	return latest, nil
+}
+
+func (f *fakeSnapshots) FindDueAccounts(_ context.Context, day time.Time, limit int) ([]*entity.Account, error) {
+	var due []*entity.Account
+	for _, acc := range f.accounts.accounts {
+		if _, ok := f.snapshots[snapshotKey(acc.ID, day)]; ok || !acc.CreatedAt.Before(day.AddDate(0, 0, 1)) {
+			continue
+		}
+		due = append(due, acc)
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func TestSnapshotChainsLateSettlement(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2024, 3, day, hour, 0, 0, 0, time.Local) }
+	settled := func(tm time.Time) *time.Time { return &tm }
+
+	accounts := newFakeAccounts(&entity.Account{ID: "acc-1", Balance: 100, Currency: "USD", Status: "ACTIVE", CreatedAt: at(1, 8)})
+	deposit := &entity.Transaction{ID: "dep", AccountID: "acc-1", Type: "DEPOSIT", Status: "SUCCESS", Amount: 100, CreatedAt: at(1, 9), ProcessedAt: settled(at(1, 9))}
+	// Created late on the 1st, still processing when the 1st is snapshotted.
+	withdrawal := &entity.Transaction{ID: "wd", AccountID: "acc-1", Type: "WITHDRAWAL", Status: "PROCESSING", Amount: 30, CreatedAt: at(1, 23)}
+	transactions := &fakeTransactions{transactions: []*entity.Transaction{deposit, withdrawal}}
+	snapshots := newFakeSnapshots(accounts)
+	handler := NewSnapshotHandler(nil, snapshots, accounts, transactions, log.DefaultLogger)
+	ctx := context.Background()
+
+	if _, err := handler.TakeDue(ctx, at(2, 2), 10); err != nil {
+		t.Fatalf("TakeDue: %v", err)
+	}
+
+	withdrawal.Status = "SUCCESS"
+	withdrawal.ProcessedAt = settled(at(2, 3))
+	accounts.accounts["acc-1"].Balance = 70
+
+	if _, err := handler.TakeDue(ctx, at(3, 2), 10); err != nil {
+		t.Fatalf("TakeDue: %v", err)
+	}
+
+	tests := []struct {
+		day         int
+		wantClosing float64
+		wantDebits  float64
+	}{
+		{day: 1, wantClosing: 100},
+		{day: 2, wantClosing: 70, wantDebits: 30},
+	}
+	for _, tt := range tests {
+		snapshot := snapshots.snapshots[snapshotKey("acc-1", at(tt.day, 0))]
+		if snapshot == nil {
+			t.Fatalf("no snapshot for day %d", tt.day)
+		}
+		if snapshot.ClosingBalance != tt.wantClosing || snapshot.Debits != tt.wantDebits {
+			t.Errorf("day %d: closing %v, debits %v, want %v, %v", tt.day, snapshot.ClosingBalance, snapshot.Debits, tt.wantClosing, tt.wantDebits)
+		}
+	}
+	if last := snapshots.snapshots[snapshotKey("acc-1", at(2, 0))]; last.ClosingBalance != accounts.accounts["acc-1"].Balance {
+		t.Errorf("last closing balance %v does not match the account balance %v", last.ClosingBalance, accounts.accounts["acc-1"].Balance)
+	}
+}
+
+func TestSnapshotGetBalanceUsesSettlementTime(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2024, 3, day, hour, 0, 0, 0, time.Local) }
+	settled := func(tm time.Time) *time.Time { return &tm }
+
+	accounts := newFakeAccounts(&entity.Account{ID: "acc-1", Balance: 70, Currency: "USD", Status: "ACTIVE", CreatedAt: at(1, 8)})
+	transactions := &fakeTransactions{transactions: []*entity.Transaction{
+		{ID: "dep", AccountID: "acc-1", Type: "DEPOSIT", Status: "SUCCESS", Amount: 100, CreatedAt: at(1, 9), ProcessedAt: settled(at(1, 9))},
+		{ID: "wd", AccountID: "acc-1", Type: "WITHDRAWAL", Status: "SUCCESS", Amount: 30, CreatedAt: at(1, 23), ProcessedAt: settled(at(2, 3))},
+		// Rows from before processed_at existed settle at their creation.
+		{ID: "legacy", AccountID: "acc-1", Type: "DEPOSIT", Status: "SUCCESS", Amount: 5, CreatedAt: at(1, 10)},
+		{ID: "legacy-fee", AccountID: "acc-1", Type: "FEE", Status: "SUCCESS", Amount: 5, CreatedAt: at(2, 10)},
+	}}
+
+	tests := []struct {
+		name     string
+		snapshot *entity.BalanceSnapshot
+		asOf     time.Time
+		want     string
+	}{
+		{name: "without snapshot before settlement", asOf: at(2, 1), want: "105.00"},
+		{name: "without snapshot after settlement", asOf: at(2, 4), want: "75.00"},
+		{name: "from the snapshot before settlement", snapshot: &entity.BalanceSnapshot{AccountID: "acc-1", Day: at(1, 0), ClosingBalance: 105}, asOf: at(2, 1), want: "105.00"},
+		{name: "from the snapshot after settlement", snapshot: &entity.BalanceSnapshot{AccountID: "acc-1", Day: at(1, 0), ClosingBalance: 105}, asOf: at(3, 0), want: "70.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshots := newFakeSnapshots(accounts)
+			if tt.snapshot != nil {
+				_ = snapshots.Save(context.Background(), tt.snapshot)
+			}
+			handler := NewSnapshotHandler(nil, snapshots, accounts, transactions, log.DefaultLogger)
+
+			resp, err := handler.GetBalance(context.Background(), &v1.GetBalanceRequest{Id: "acc-1", AsOf: tt.asOf.Format(time.RFC3339)})
+			if err != nil {
+				t.Fatalf("GetBalance: %v", err)
+			}
+			if resp.Balance != tt.want {
+				t.Fatalf("balance = %s, want %s", resp.Balance, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package biz
+
+import (
+	"bank-ledger/internal/entity"
+	"context"
+	"math"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+const statementDateLayout = "2006-01-02"
+
+const (
+	StatementFormatJSON    = "json"
+	StatementFormatCSV     = "csv"
+	StatementFormatOFX     = "ofx"
+	StatementFormatCAMT053 = "camt053"
+)
+
+func (t *Transaction) GetStatement(ctx context.Context, req *v1.GetStatementRequest) (*v1.GetStatementResponse, error) {
+	if req.AccountId == "" {
+		return nil, errors.BadRequest("ACCOUNT_ID_REQUIRED", "account_id is required")
+	}
+
+	switch req.Format {
+	case "", StatementFormatJSON, StatementFormatCSV, StatementFormatOFX, StatementFormatCAMT053:
+	default:
+		return nil, errors.BadRequest("INVALID_FORMAT", "format must be one of json, csv, ofx or camt053")
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	to := now
+
+	var err error
+	if req.From != "" {
+		if from, err = parseStatementTime(req.From, false); err != nil {
+			return nil, errors.BadRequest("INVALID_FROM", "from must be an RFC3339 timestamp or YYYY-MM-DD date")
+		}
+	}
+	if req.To != "" {
+		if to, err = parseStatementTime(req.To, true); err != nil {
+			return nil, errors.BadRequest("INVALID_TO", "to must be an RFC3339 timestamp or YYYY-MM-DD date")
+		}
+	}
+	if !from.Before(to) {
+		return nil, errors.BadRequest("INVALID_RANGE", "from must be before to")
+	}
+
+	account, err := t.acc.FindByID(ctx, &v1.BaseRequest{Id: req.AccountId})
+	if err != nil {
+		return nil, errors.NotFound("ACCOUNT_NOT_FOUND", "account not found")
+	}
+
+	opening, err := t.trx.SumSuccessBefore(ctx, req.AccountId, from)
+	if err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+
+	trxs, err := t.trx.FindSuccessInRange(ctx, req.AccountId, from, to)
+	if err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+
+	running := roundAmount(opening)
+	var credits, debits float64
+	lines := make([]*v1.StatementLine, 0, len(trxs))
+	for _, tx := range trxs {
+		amount := SignedAmount(tx)
+		if amount >= 0 {
+			credits += amount
+		} else {
+			debits -= amount
+		}
+		running = roundAmount(running + amount)
+
+		lines = append(lines, &v1.StatementLine{
+			TransactionId:         tx.ID,
+			BookedAt:              tx.CreatedAt.Format(time.RFC3339),
+			Type:                  v1.TransactionType(v1.TransactionType_value[tx.Type]),
+			Description:           tx.Description,
+			Amount:                amount,
+			RunningBalance:        running,
+			ParentTransactionId:   tx.ParentTransactionID,
+			CounterpartyAccountId: tx.CounterpartyAccountID,
+		})
+	}
+
+	return &v1.GetStatementResponse{
+		AccountId:      account.ID,
+		AccountNumber:  account.AccountNumber,
+		AccountName:    account.Name,
+		Currency:       account.Currency,
+		From:           from.Format(time.RFC3339),
+		To:             to.Format(time.RFC3339),
+		OpeningBalance: roundAmount(opening),
+		ClosingBalance: running,
+		TotalCredits:   roundAmount(credits),
+		TotalDebits:    roundAmount(debits),
+		Lines:          lines,
+	}, nil
+}
+
+// SignedAmount returns the effect a transaction has on its account's balance.
+func SignedAmount(tx *entity.Transaction) float64 {
+	if tx.Type == v1.TransactionType_DEPOSIT.String() {
+		return tx.Amount
+	}
+	return -tx.Amount
+}
+
+// parseStatementTime accepts an RFC3339 timestamp or a plain date. A plain date
+// used as the end of a range covers that whole day.
+func parseStatementTime(value string, end bool) (time.Time, error) {
+	if ts, err := time.Parse(time.RFC3339, value); err == nil {
+		return ts, nil
+	}
+	day, err := time.ParseInLocation(statementDateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		return day.AddDate(0, 0, 1), nil
+	}
+	return day, nil
+}
+
+func roundAmount(val float64) float64 {
+	return math.Round(val*100) / 100
+}
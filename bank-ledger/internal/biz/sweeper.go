@@ -0,0 +1,196 @@
+package biz
+
+import (
+	"bank-ledger/internal/conf"
+	"bank-ledger/internal/data"
+	"bank-ledger/internal/entity"
+	"bank-ledger/internal/kafka"
+	"bank-ledger/internal/metrics"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+// Sweeper policies for stuck transactions.
+const (
+	SweepPolicyRepublish = "republish"
+	SweepPolicyFail      = "fail"
+)
+
+type TransactionSweeper interface {
+	Sweep(ctx context.Context, limit int) (int, error)
+}
+
+type Sweeper struct {
+	data       *data.Data
+	trx        data.TransactionRepository
+	trxLog     data.TransactionLogsRepository
+	producer   kafka.Producer
+	topics     *Topics
+	failures   *TransactionFailures
+	thresholds map[string]time.Duration
+	policy     string
+	maxRetries int
+	log        *log.Helper
+}
+
+// swept is a transaction handled by a sweep, with the log entry explaining it.
+type swept struct {
+	trx     *entity.Transaction
+	attempt int
+	entry   entity.LogEntry
+}
+
+func NewTransactionSweeper(c *conf.Server, d *data.Data, trx data.TransactionRepository, trxLogs data.TransactionLogsRepository, producer kafka.Producer, topics *Topics, failures *TransactionFailures, logger log.Logger) TransactionSweeper {
+	s := &Sweeper{
+		data:     d,
+		trx:      trx,
+		trxLog:   trxLogs,
+		producer: producer,
+		topics:   topics,
+		failures: failures,
+		thresholds: map[string]time.Duration{
+			v1.TransactionStatus_INITIATED.String():  5 * time.Minute,
+			v1.TransactionStatus_PROCESSING.String(): 5 * time.Minute,
+		},
+		policy:     SweepPolicyRepublish,
+		maxRetries: 5,
+		log:        log.NewHelper(log.With(logger, "module", "biz/sweeper")),
+	}
+	if c != nil && c.Sweeper != nil {
+		if c.Sweeper.InitiatedAfter != nil {
+			s.thresholds[v1.TransactionStatus_INITIATED.String()] = c.Sweeper.InitiatedAfter.AsDuration()
+		}
+		if c.Sweeper.ProcessingAfter != nil {
+			s.thresholds[v1.TransactionStatus_PROCESSING.String()] = c.Sweeper.ProcessingAfter.AsDuration()
+		}
+		switch policy := strings.ToLower(c.Sweeper.Policy); policy {
+		case "", SweepPolicyRepublish:
+		case SweepPolicyFail:
+			s.policy = policy
+		default:
+			s.log.Warnf("unknown sweeper policy %q, using %s", c.Sweeper.Policy, SweepPolicyRepublish)
+		}
+		if c.Sweeper.MaxRetries > 0 {
+			s.maxRetries = int(c.Sweeper.MaxRetries)
+		}
+	}
+	return s
+}
+
+// Sweep handles up to limit stuck transactions of each status and returns how
+// many it republished or failed.
+func (s *Sweeper) Sweep(ctx context.Context, limit int) (int, error) {
+	total := 0
+	for _, status := range []string{v1.TransactionStatus_INITIATED.String(), v1.TransactionStatus_PROCESSING.String()} {
+		n, err := s.sweep(ctx, status, time.Now().Add(-s.thresholds[status]), limit)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (s *Sweeper) sweep(ctx context.Context, status string, before time.Time, limit int) (int, error) {
+	if count, err := s.trx.CountStale(ctx, status, before); err != nil {
+		s.log.Errorf("failed to count stuck %s transactions: %v", status, err)
+	} else {
+		metrics.StuckTransactions.WithLabelValues(status).Set(float64(count))
+	}
+
+	var handled []swept
+	err := s.data.DB().Transaction(func(tx *gorm.DB) error {
+		handled = handled[:0]
+		repo := s.trx.WithTx(tx)
+
+		stuck, err := repo.LockStale(ctx, status, before, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, trx := range stuck {
+			stuckFor := time.Since(trx.UpdatedAt).Round(time.Second)
+			attempt := trx.RetryCount
+			if attempt == 0 {
+				attempt = 1
+			}
+
+			if s.policy == SweepPolicyFail || trx.SweepAttempts >= s.maxRetries {
+				reason := fmt.Sprintf("Transaction failed by sweeper after %s in %s", stuckFor, status)
+				if err := s.failures.markFailed(ctx, tx, trx, reason); err != nil {
+					return fmt.Errorf("failed to fail transaction %s: %w", trx.ID, err)
+				}
+				handled = append(handled, swept{trx: trx, attempt: attempt, entry: entity.LogEntry{
+					Message: reason,
+					Status:  trx.Status,
+				}})
+				continue
+			}
+
+			// Every attempt counts, sent or not, so a command that cannot be
+			// published still reaches max_retries and fails. Touching the row
+			// restarts the threshold, giving the consumer time to apply the
+			// command before the next sweep.
+			trx.SweepAttempts++
+			sendErr := s.republish(ctx, trx)
+			if err := repo.Update(ctx, trx); err != nil {
+				return fmt.Errorf("failed to touch transaction %s: %w", trx.ID, err)
+			}
+			if sendErr != nil {
+				s.log.Errorf("failed to republish transaction %s: %v", trx.ID, sendErr)
+				metrics.SweptTransactions.WithLabelValues(status, metrics.SweepError).Inc()
+				continue
+			}
+			handled = append(handled, swept{trx: trx, attempt: attempt, entry: entity.LogEntry{
+				Message: fmt.Sprintf("Transaction command republished by sweeper after %s in %s (attempt %d of %d)", stuckFor, status, trx.SweepAttempts, s.maxRetries),
+				Status:  status,
+			}})
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to sweep %s transactions: %w", status, err)
+	}
+
+	for _, h := range handled {
+		s.record(ctx, status, h)
+	}
+	return len(handled), nil
+}
+
+// republish sends the transaction's command to the consumer again. The
+// consumer's inbox makes this safe if the first command was applied after all.
+func (s *Sweeper) republish(ctx context.Context, trx *entity.Transaction) error {
+	cmd := NewTransactionCommand(trx)
+	value, headers, err := EncodeTransactionCommand(cmd)
+	if err != nil {
+		return err
+	}
+	pubCtx, span := kafka.StartProducerSpan(ctx, s.topics.Transactions)
+	defer span.End()
+	headers = append(headers, kafka.ContextHeaders(pubCtx)...)
+	return s.producer.SendMessage(s.topics.Transactions, []byte(CommandKey(cmd)), value, headers...)
+}
+
+// record logs a committed sweep action; failed transactions are announced
+// like any other failure.
+func (s *Sweeper) record(ctx context.Context, status string, h swept) {
+	if h.trx.Status == v1.TransactionStatus_FAILED.String() {
+		metrics.SweptTransactions.WithLabelValues(status, metrics.SweepFailed).Inc()
+		s.failures.notify(ctx, h.trx, h.entry.Message)
+		return
+	}
+	metrics.SweptTransactions.WithLabelValues(status, metrics.SweepRepublished).Inc()
+
+	entry := StampLogEntry(ctx, h.entry)
+	entry.Timestamp = time.Now()
+	if err := s.trxLog.AppendTransactionLog(ctx, h.trx.ID, h.attempt, entry); err != nil {
+		s.log.Errorf("failed to append transaction log for %s: %v", h.trx.ID, err)
+	}
+}
@@ -0,0 +1,111 @@
+package biz
+
+import (
+	"bank-ledger/internal/conf"
+	"bank-ledger/internal/entity"
+	"bank-ledger/internal/kafka"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeProducer counts the messages sent to each topic, failing every send to
+// failTopic.
+type fakeProducer struct {
+	sent      map[string]int
+	failTopic string
+}
+
+func (p *fakeProducer) SendMessage(topic string, _, _ []byte, _ ...kafka.Header) error {
+	if topic == p.failTopic {
+		return errors.New("broker unavailable")
+	}
+	p.sent[topic]++
+	return nil
+}
+
+func (p *fakeProducer) SendMessageAsync(topic string, key, value []byte, done kafka.DeliveryFunc, headers ...kafka.Header) error {
+	err := p.SendMessage(topic, key, value, headers...)
+	if done != nil {
+		done(err)
+	}
+	return nil
+}
+
+func (p *fakeProducer) Close() error { return nil }
+
+// fakeWebhooks counts the webhooks enqueued.
+type fakeWebhooks struct {
+	WebhookHandler
+	enqueued int
+}
+
+func (f *fakeWebhooks) Enqueue(context.Context, string, proto.Message, ...string) error {
+	f.enqueued++
+	return nil
+}
+
+func TestSweeperFailsAfterMaxRetries(t *testing.T) {
+	topics := &Topics{Transactions: "transactions", TransactionStatus: "transaction-status"}
+
+	tests := []struct {
+		name      string
+		failTopic string
+		// wantRepublished is how many commands reached the broker.
+		wantRepublished int
+	}{
+		{name: "republished commands are never applied", wantRepublished: 3},
+		{name: "republishing keeps failing", failTopic: topics.Transactions, wantRepublished: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The fake leaves updated_at alone, so each sweep finds the row
+			// stale again, as if the threshold had passed in between.
+			transactions := &fakeTransactions{transactions: []*entity.Transaction{{
+				ID:        "trx-1",
+				AccountID: "acc-1",
+				Type:      v1.TransactionType_DEPOSIT.String(),
+				Status:    v1.TransactionStatus_INITIATED.String(),
+				Amount:    10,
+				UpdatedAt: time.Now().Add(-time.Hour),
+			}}}
+			producer := &fakeProducer{sent: make(map[string]int), failTopic: tt.failTopic}
+			webhooks := &fakeWebhooks{}
+			d := newTestData(t)
+			failures := NewTransactionFailures(d, transactions, &fakeLogs{}, &fakeOutbox{}, producer, topics, webhooks, log.DefaultLogger)
+			sweeper := NewTransactionSweeper(&conf.Server{Sweeper: &conf.Server_Sweeper{MaxRetries: 3}}, d, transactions, &fakeLogs{}, producer, topics, failures, log.DefaultLogger)
+
+			for i := 1; i <= 3; i++ {
+				if _, err := sweeper.Sweep(context.Background(), 10); err != nil {
+					t.Fatalf("sweep %d: %v", i, err)
+				}
+				trx := transactions.transactions[0]
+				if trx.Status != v1.TransactionStatus_INITIATED.String() {
+					t.Fatalf("after sweep %d status = %s, want INITIATED", i, trx.Status)
+				}
+				if trx.SweepAttempts != i {
+					t.Fatalf("after sweep %d sweep attempts = %d, want %d", i, trx.SweepAttempts, i)
+				}
+			}
+
+			if _, err := sweeper.Sweep(context.Background(), 10); err != nil {
+				t.Fatalf("final sweep: %v", err)
+			}
+			if got := transactions.transactions[0].Status; got != v1.TransactionStatus_FAILED.String() {
+				t.Fatalf("status = %s, want FAILED", got)
+			}
+			if got := producer.sent[topics.Transactions]; got != tt.wantRepublished {
+				t.Errorf("%d commands republished, want %d", got, tt.wantRepublished)
+			}
+			if webhooks.enqueued != 1 {
+				t.Errorf("%d webhooks enqueued, want 1", webhooks.enqueued)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package biz
+
+import "bank-ledger/internal/conf"
+
+// Topics are the Kafka topic names in use. The package constants are the
+// defaults, replaced by any name set in conf.Data.Kafka.Topics.
+type Topics struct {
+	Transactions           string
+	TransactionsDeadLetter string
+	TransactionStatus      string
+	LedgerEvents           string
+}
+
+func NewTopics(c *conf.Data) *Topics {
+	t := c.GetKafka().GetTopics()
+	return &Topics{
+		Transactions:           orDefault(t.GetTransactions(), TransactionsTopic),
+		TransactionsDeadLetter: orDefault(t.GetTransactionsDeadLetter(), TransactionsDeadLetterTopic),
+		TransactionStatus:      orDefault(t.GetTransactionStatus(), TransactionStatusTopic),
+		LedgerEvents:           orDefault(t.GetLedgerEvents(), LedgerEventsTopic),
+	}
+}
+
+// Resolve maps a default topic name, as recorded in outbox rows, to its
+// configured name.
+func (t *Topics) Resolve(name string) string {
+	switch name {
+	case TransactionsTopic:
+		return t.Transactions
+	case TransactionsDeadLetterTopic:
+		return t.TransactionsDeadLetter
+	case TransactionStatusTopic:
+		return t.TransactionStatus
+	case LedgerEventsTopic:
+		return t.LedgerEvents
+	}
+	return name
+}
+
+func orDefault(val string, def string) string {
+	if val == "" {
+		return def
+	}
+	return val
+}
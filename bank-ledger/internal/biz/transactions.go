@@ -4,38 +4,49 @@ import (
 	"bank-ledger/internal/data"
 	"bank-ledger/internal/entity"
 	"context"
-	"encoding/json"
+	"fmt"
 	"github.com/go-kratos/kratos/v2/errors"
 	"net/http"
 	"time"
 
 	v1 "bank-ledger/api/bankLedger/v1"
 	"bank-ledger/internal/kafka"
+	"bank-ledger/internal/metrics"
+	"bank-ledger/internal/propagation"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/rs/xid"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type TransactionHandler interface {
 	Create(ctx context.Context, req *v1.CreateTransactionRequest) (*v1.CreateTransactionResponse, error)
 	GetTransactionById(ctx context.Context, req *v1.GetTransactionByIdRequest) (*v1.GetTransactionResponse, error)
 	GetTransactionsByAccount(ctx context.Context, req *v1.GetTransactionsByAccountRequest) (*v1.GetTransactionsByAccountResponse, error)
+	GetStatement(ctx context.Context, req *v1.GetStatementRequest) (*v1.GetStatementResponse, error)
 }
 
 type Transaction struct {
 	log      *log.Helper
 	producer kafka.Producer
+	topics   *Topics
 	acc      data.AccountRepository
 	trx      data.TransactionRepository
 	trxLog   data.TransactionLogsRepository
+	seq      data.SequenceRepository
+	failures *TransactionFailures
 }
 
-func NewTransactionHandler(producer kafka.Producer, logger log.Logger, acc data.AccountRepository, trx data.TransactionRepository, trxLogs data.TransactionLogsRepository) TransactionHandler {
+func NewTransactionHandler(producer kafka.Producer, topics *Topics, logger log.Logger, acc data.AccountRepository, trx data.TransactionRepository, trxLogs data.TransactionLogsRepository, seq data.SequenceRepository, failures *TransactionFailures) TransactionHandler {
 	return &Transaction{
 		producer: producer,
+		topics:   topics,
 		log:      log.NewHelper(logger),
 		acc:      acc,
 		trx:      trx,
 		trxLog:   trxLogs,
+		seq:      seq,
+		failures: failures,
 	}
 }
 
@@ -45,6 +56,26 @@ func (t *Transaction) Create(ctx context.Context, req *v1.CreateTransactionReque
 		return nil, errors.New(http.StatusNotFound, http.StatusText(http.StatusNotFound), "account_id is required")
 	}
 
+	if req.Type == v1.TransactionType_FEE {
+		return nil, errors.New(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "fee transactions cannot be created directly")
+	}
+
+	if req.Amount <= 0 {
+		return nil, errors.BadRequest("INVALID_AMOUNT", "amount must be positive")
+	}
+
+	if req.IdempotencyKey != "" {
+		existing, err := t.trx.FindByIdempotencyKey(ctx, req.IdempotencyKey)
+		if err == nil {
+			return &v1.CreateTransactionResponse{
+				TransactionId: existing.ID,
+				AccountId:     existing.AccountID,
+				Status:        v1.TransactionStatus(v1.TransactionStatus_value[existing.Status]),
+				CreatedAt:     existing.CreatedAt.Format(time.RFC3339),
+			}, nil
+		}
+	}
+
 	acc, err := t.acc.FindByID(ctx, &v1.BaseRequest{Id: req.AccountId})
 	if err != nil {
 		return nil, errors.New(http.StatusNotFound, http.StatusText(http.StatusNotFound), "account does not exist")
@@ -54,24 +85,67 @@ func (t *Transaction) Create(ctx context.Context, req *v1.CreateTransactionReque
 		return nil, errors.New(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "account is closed")
 	}
 
-	if req.Type == v1.TransactionType_WITHDRAWAL && acc.Balance < req.Amount {
+	if acc.Status == v1.AccountStatus_FROZEN.String() {
+		return nil, errors.New(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "account is frozen")
+	}
+
+	if (req.Type == v1.TransactionType_WITHDRAWAL || req.Type == v1.TransactionType_TRANSFER) && acc.Balance < req.Amount {
 		return nil, errors.New(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "insufficient balance")
 	}
 
+	if req.Type == v1.TransactionType_TRANSFER {
+		if req.CounterpartyAccountId == "" || req.CounterpartyAccountId == req.AccountId {
+			return nil, errors.New(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "a different counterparty_account_id is required for transfers")
+		}
+
+		counterparty, err := t.acc.FindByID(ctx, &v1.BaseRequest{Id: req.CounterpartyAccountId})
+		if err != nil {
+			return nil, errors.New(http.StatusNotFound, http.StatusText(http.StatusNotFound), "counterparty account does not exist")
+		}
+
+		if counterparty.Status == v1.AccountStatus_CLOSED.String() {
+			return nil, errors.New(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "counterparty account is closed")
+		}
+
+		if counterparty.Status == v1.AccountStatus_FROZEN.String() {
+			return nil, errors.New(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "counterparty account is frozen")
+		}
+
+		if counterparty.Currency != acc.Currency {
+			return nil, errors.New(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "counterparty account currency does not match")
+		}
+	}
+
+	var idempotencyKey *string
+	if req.IdempotencyKey != "" {
+		idempotencyKey = &req.IdempotencyKey
+	}
+
 	transactionID := xid.New().String()
 
+	// A failure after the number is issued leaves a gap, which the consumer
+	// reports but tolerates.
+	sequence, err := t.seq.Next(ctx, req.AccountId)
+	if err != nil {
+		t.log.WithContext(ctx).Errorf("failed to issue sequence for account %s: %v", req.AccountId, err)
+		return nil, errors.New(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), "failed to create transaction")
+	}
+
 	now := time.Now()
 	createdAt := now.Format(time.RFC3339)
 	if err := t.trx.Create(ctx, &entity.Transaction{
-		ID:          transactionID,
-		AccountID:   req.AccountId,
-		Amount:      req.Amount,
-		Type:        req.Type.String(),
-		Description: req.Description,
-		Currency:    acc.Currency,
-		Status:      v1.TransactionStatus_INITIATED.String(),
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:                    transactionID,
+		AccountID:             req.AccountId,
+		Amount:                req.Amount,
+		Type:                  req.Type.String(),
+		Description:           req.Description,
+		Currency:              acc.Currency,
+		Status:                v1.TransactionStatus_INITIATED.String(),
+		CreatedAt:             now,
+		UpdatedAt:             now,
+		CounterpartyAccountID: req.CounterpartyAccountId,
+		IdempotencyKey:        idempotencyKey,
+		Sequence:              sequence,
 	}); err != nil {
 		return nil, errors.New(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), "failed to create transaction")
 	}
@@ -87,43 +161,55 @@ func (t *Transaction) Create(ctx context.Context, req *v1.CreateTransactionReque
 				Attempt:   1,
 				Timestamp: now,
 				Logs: []entity.LogEntry{
-					{
+					StampLogEntry(ctx, entity.LogEntry{
 						Timestamp: now,
 						Message:   "Transaction initiated and event published to Kafka successfully",
 						Status:    v1.TransactionStatus_INITIATED.String(),
-					},
+					}),
 				},
 			},
 		},
 	})
 	if err != nil {
-		t.log.Errorf("failed to create transaction log in MongoDB: %v", err)
-		// Optional: Decide whether to fail or continue. Here we continue.
+		t.log.WithContext(ctx).Errorf("failed to create transaction log in MongoDB: %v", err)
+		// Continue: MySQL is the source of truth and bank-ledger-logcheck
+		// repairs missing logs from it.
 	}
 
-	kafkaMessage := map[string]interface{}{
-		"transaction_id": transactionID,
-		"account_id":     req.AccountId,
-		"amount":         req.Amount,
-		"type":           req.Type.String(),
-		"description":    req.Description,
-		"currency":       acc.Currency,
-		"status":         v1.TransactionStatus_INITIATED.String(),
-		"created_at":     createdAt,
+	cmd := &v1.TransactionCommand{
+		TransactionId:         transactionID,
+		AccountId:             req.AccountId,
+		CounterpartyAccountId: req.CounterpartyAccountId,
+		Amount:                req.Amount,
+		Type:                  req.Type,
+		Description:           req.Description,
+		Currency:              acc.Currency,
+		Status:                v1.TransactionStatus_INITIATED,
+		CreatedAt:             createdAt,
+		Sequence:              sequence,
 	}
-
-	messageBytes, err := json.Marshal(kafkaMessage)
+	value, headers, err := EncodeTransactionCommand(cmd)
 	if err != nil {
-		t.log.Errorf("failed to marshal transaction for kafka: %v", err)
+		t.log.WithContext(ctx).Errorf("failed to encode transaction command: %v", err)
 		return nil, err
 	}
 
-	err = t.producer.SendMessage("transactions", []byte(transactionID), messageBytes)
+	pubCtx, span := kafka.StartProducerSpan(ctx, t.topics.Transactions)
+	headers = append(headers, kafka.ContextHeaders(pubCtx)...)
+	err = t.producer.SendMessageAsync(t.topics.Transactions, []byte(CommandKey(cmd)), value, t.onDelivery(ctx, transactionID), headers...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
 	if err != nil {
-		t.log.Errorf("failed to publish transaction to kafka: %v", err)
+		t.log.WithContext(ctx).Errorf("failed to publish transaction to kafka: %v", err)
 		return nil, err
 	}
 
+	metrics.TransactionsCreated.WithLabelValues(req.Type.String()).Inc()
+	metrics.TransactionAmount.WithLabelValues(req.Type.String()).Add(req.Amount)
+
 	return &v1.CreateTransactionResponse{
 		TransactionId: transactionID,
 		AccountId:     req.AccountId,
@@ -132,6 +218,23 @@ func (t *Transaction) Create(ctx context.Context, req *v1.CreateTransactionReque
 	}, nil
 }
 
+// onDelivery fails a transaction whose command the async producer could not
+// deliver, so it does not sit in INITIATED forever.
+func (t *Transaction) onDelivery(ctx context.Context, transactionID string) kafka.DeliveryFunc {
+	ctx = context.WithoutCancel(ctx)
+	return func(err error) {
+		if err == nil {
+			return
+		}
+		t.log.WithContext(ctx).Errorf("failed to deliver transaction %s to kafka: %v", transactionID, err)
+
+		// Callbacks run on the producer's result loop, which publishing the
+		// status event waits on, so fail the transaction off it.
+		reason := fmt.Sprintf("Failed to publish transaction to Kafka: %v", err)
+		t.failures.FailAsync(ctx, transactionID, v1.TransactionStatus_INITIATED.String(), reason)
+	}
+}
+
 func (t *Transaction) GetTransactionById(ctx context.Context, req *v1.GetTransactionByIdRequest) (*v1.GetTransactionResponse, error) {
 	if req.TransactionId == "" {
 		return nil, errors.New(400, "BAD_REQUEST", "transaction_id is required")
@@ -159,18 +262,8 @@ func (t *Transaction) GetTransactionById(ctx context.Context, req *v1.GetTransac
 	}
 
 	return &v1.GetTransactionResponse{
-		Transaction: &v1.EachTransaction{
-			Id:          trx.ID,
-			AccountId:   trx.AccountID,
-			Amount:      trx.Amount,
-			Type:        v1.TransactionType(v1.TransactionType_value[trx.Type]),
-			Description: trx.Description,
-			Currency:    trx.Currency,
-			Status:      v1.TransactionStatus(v1.TransactionStatus_value[trx.Status]),
-			CreatedAt:   trx.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:   trx.UpdatedAt.Format(time.RFC3339),
-		},
-		Logs: protoLogs,
+		Transaction: ToProtoTransaction(trx),
+		Logs:        protoLogs,
 	}, nil
 }
 
@@ -179,41 +272,54 @@ func (t *Transaction) GetTransactionsByAccount(ctx context.Context, req *v1.GetT
 		return nil, errors.BadRequest("ACCOUNT_ID_REQUIRED", "account_id is required")
 	}
 
-	offset := (req.Page - 1) * req.PageSize
-	limit := req.PageSize
+	filter, err := transactionFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Offset paging is gone; a later page without a cursor cannot be served,
+	// and silently returning the first page would repeat rows.
+	if req.Page > 1 && req.PageToken == "" {
+		return nil, errors.BadRequest("PAGE_UNSUPPORTED", "page is no longer supported, use page_token")
+	}
+
+	var after *data.TransactionCursor
+	if req.PageToken != "" {
+		createdAt, id, ok := decodePageToken(req.PageToken)
+		if !ok {
+			return nil, errors.BadRequest("INVALID_PAGE_TOKEN", "page_token is invalid")
+		}
+		after = &data.TransactionCursor{CreatedAt: createdAt, ID: id}
+	}
+
+	pageSize := normalizePageSize(req.PageSize)
 
-	trxs, total, err := t.trx.FindByAccountIDWithPagination(ctx, req.AccountId, int(offset), int(limit))
+	// Fetch one extra row to learn whether another page follows.
+	trxs, err := t.trx.FindByAccountIDAfter(ctx, req.AccountId, filter, after, pageSize+1)
 	if err != nil {
 		return nil, errors.InternalServer("DB_ERROR", err.Error())
 	}
 
+	var nextPageToken string
+	if len(trxs) > pageSize {
+		trxs = trxs[:pageSize]
+		last := trxs[len(trxs)-1]
+		nextPageToken = encodePageToken(last.CreatedAt, last.ID)
+	}
+
 	account, _ := t.acc.FindByID(ctx, &v1.BaseRequest{Id: req.AccountId})
 
 	var result []*v1.EachTransaction
 	for _, tx := range trxs {
-		result = append(result, &v1.EachTransaction{
-			Id:          tx.ID,
-			AccountId:   tx.AccountID,
-			Amount:      tx.Amount,
-			Type:        v1.TransactionType(v1.TransactionType_value[tx.Type]),
-			Description: tx.Description,
-			Currency:    tx.Currency,
-			Status:      v1.TransactionStatus(v1.TransactionStatus_value[tx.Status]),
-			CreatedAt:   tx.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:   tx.UpdatedAt.Format(time.RFC3339),
-		})
+		result = append(result, ToProtoTransaction(tx))
 	}
 
-	totalPages := (int32(total) + req.PageSize - 1) / req.PageSize
-
 	return &v1.GetTransactionsByAccountResponse{
 		AccountId:    req.AccountId,
 		Transactions: result,
 		Pagination: &v1.PaginationInfo{
-			TotalCount: int32(total),
-			Page:       req.Page,
-			PageSize:   req.PageSize,
-			TotalPages: totalPages,
+			PageSize:      int32(pageSize),
+			NextPageToken: nextPageToken,
 		},
 		AccountInfo: &v1.AccountInfo{
 			Id:       account.ID,
@@ -223,3 +329,65 @@ func (t *Transaction) GetTransactionsByAccount(ctx context.Context, req *v1.GetT
 		},
 	}, nil
 }
+
+func ToProtoTransaction(trx *entity.Transaction) *v1.EachTransaction {
+	return &v1.EachTransaction{
+		Id:                    trx.ID,
+		AccountId:             trx.AccountID,
+		Amount:                trx.Amount,
+		Type:                  v1.TransactionType(v1.TransactionType_value[trx.Type]),
+		Description:           trx.Description,
+		Currency:              trx.Currency,
+		Status:                v1.TransactionStatus(v1.TransactionStatus_value[trx.Status]),
+		CreatedAt:             trx.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:             trx.UpdatedAt.Format(time.RFC3339),
+		ParentTransactionId:   trx.ParentTransactionID,
+		CounterpartyAccountId: trx.CounterpartyAccountID,
+	}
+}
+
+func transactionFilter(req *v1.GetTransactionsByAccountRequest) (data.TransactionFilter, error) {
+	filter := data.TransactionFilter{
+		MinAmount:   req.MinAmount,
+		MaxAmount:   req.MaxAmount,
+		Description: req.Description,
+	}
+
+	for _, typ := range req.Types {
+		filter.Types = append(filter.Types, typ.String())
+	}
+	for _, status := range req.Statuses {
+		filter.Statuses = append(filter.Statuses, status.String())
+	}
+
+	if filter.MinAmount < 0 || filter.MaxAmount < 0 || (filter.MaxAmount > 0 && filter.MinAmount > filter.MaxAmount) {
+		return filter, errors.BadRequest("INVALID_AMOUNT_RANGE", "min_amount and max_amount must be positive and min_amount must not exceed max_amount")
+	}
+
+	var err error
+	if req.From != "" {
+		if filter.From, err = parseStatementTime(req.From, false); err != nil {
+			return filter, errors.BadRequest("INVALID_FROM", "from must be an RFC3339 timestamp or YYYY-MM-DD date")
+		}
+	}
+	if req.To != "" {
+		if filter.To, err = parseStatementTime(req.To, true); err != nil {
+			return filter, errors.BadRequest("INVALID_TO", "to must be an RFC3339 timestamp or YYYY-MM-DD date")
+		}
+	}
+	return filter, nil
+}
+
+// StampLogEntry copies the request, correlation and trace ids carried by ctx
+// onto a transaction log entry.
+func StampLogEntry(ctx context.Context, entry entity.LogEntry) entity.LogEntry {
+	if v, ok := propagation.FromContext(ctx); ok {
+		entry.RequestID = v.RequestID
+		entry.CorrelationID = v.CorrelationID
+		entry.TraceID = v.TraceID()
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		entry.TraceID = sc.TraceID().String()
+	}
+	return entry
+}
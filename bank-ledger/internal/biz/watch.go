@@ -0,0 +1,202 @@
+package biz
+
+import (
+	"bank-ledger/internal/data"
+	"bank-ledger/internal/entity"
+	"context"
+	"sync"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TransactionStatusTopic is the default topic carrying a TransactionEvent for every status change and
+// log entry the consumer records.
+const TransactionStatusTopic = "transaction-status"
+
+// watcherBuffer is how many events a slow watcher may fall behind before it is
+// disconnected.
+const watcherBuffer = 64
+
+type TransactionWatcher interface {
+	Watch(ctx context.Context, req *v1.WatchTransactionRequest, send func(*v1.TransactionEvent) error) error
+	Publish(key, value []byte)
+}
+
+type Watcher struct {
+	trx    data.TransactionRepository
+	trxLog data.TransactionLogsRepository
+	log    *log.Helper
+
+	mu       sync.Mutex
+	watchers map[string]map[chan *v1.TransactionEvent]struct{}
+}
+
+func NewTransactionWatcher(trx data.TransactionRepository, trxLog data.TransactionLogsRepository, logger log.Logger) TransactionWatcher {
+	return &Watcher{
+		trx:      trx,
+		trxLog:   trxLog,
+		log:      log.NewHelper(log.With(logger, "module", "biz/watch")),
+		watchers: make(map[string]map[chan *v1.TransactionEvent]struct{}),
+	}
+}
+
+// NewTransactionEvent builds the event published for a recorded log entry.
+func NewTransactionEvent(trx *entity.Transaction, attempt int, entry entity.LogEntry) *v1.TransactionEvent {
+	return &v1.TransactionEvent{
+		TransactionId: trx.ID,
+		AccountId:     trx.AccountID,
+		Status:        v1.TransactionStatus(v1.TransactionStatus_value[entry.Status]),
+		Message:       entry.Message,
+		Attempt:       int32(attempt),
+		Timestamp:     entry.Timestamp.Format(time.RFC3339),
+	}
+}
+
+// Watch replays the transaction's recorded log and then forwards live events
+// until the transaction settles or ctx is done.
+func (w *Watcher) Watch(ctx context.Context, req *v1.WatchTransactionRequest, send func(*v1.TransactionEvent) error) error {
+	if req.TransactionId == "" {
+		return errors.BadRequest("TRANSACTION_ID_REQUIRED", "transaction_id is required")
+	}
+
+	// Subscribe before reading the snapshot so no event falls in between.
+	events := w.subscribe(req.TransactionId)
+	defer w.unsubscribe(req.TransactionId, events)
+
+	trx, err := w.trx.FindByID(ctx, &v1.BaseRequest{Id: req.TransactionId})
+	if err != nil {
+		return errors.NotFound("TRANSACTION_NOT_FOUND", "transaction not found")
+	}
+
+	// Events published after subscribing may also be in the log by now; the
+	// replayed ones are remembered so they are not sent twice.
+	replayed := make(map[replayKey]struct{})
+	settled := isSettled(trx.Status)
+	logs, _ := w.trxLog.GetTransaction(ctx, req.TransactionId)
+	if logs != nil {
+		for _, try := range logs.TransactionLogs {
+			for _, l := range try.Logs {
+				event := NewTransactionEvent(trx, try.Attempt, l)
+				if err := send(event); err != nil {
+					return err
+				}
+				replayed[keyOf(event)] = struct{}{}
+				settled = settled || isSettled(l.Status)
+			}
+		}
+	}
+
+	if settled {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return w.caughtUp(ctx, req.TransactionId, send)
+			}
+			if _, ok := replayed[keyOf(event)]; ok {
+				continue
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+			if isSettled(event.Status.String()) {
+				return nil
+			}
+		}
+	}
+}
+
+// replayKey identifies a log entry in both its replayed and published form.
+// The time is compared as an instant, since MongoDB hands entries back in UTC.
+type replayKey struct {
+	attempt   int32
+	timestamp int64
+	status    v1.TransactionStatus
+	message   string
+}
+
+func keyOf(event *v1.TransactionEvent) replayKey {
+	key := replayKey{attempt: event.Attempt, status: event.Status, message: event.Message}
+	if ts, err := time.Parse(time.RFC3339, event.Timestamp); err == nil {
+		key.timestamp = ts.Unix()
+	}
+	return key
+}
+
+// caughtUp ends the stream of a watcher disconnected for falling behind. Events
+// were lost, so a settled transaction gets its final state from the database;
+// otherwise the client must watch again to replay the log.
+func (w *Watcher) caughtUp(ctx context.Context, transactionID string, send func(*v1.TransactionEvent) error) error {
+	trx, err := w.trx.FindByID(ctx, &v1.BaseRequest{Id: transactionID})
+	if err != nil {
+		return errors.InternalServer("DB_ERROR", err.Error())
+	}
+	if !isSettled(trx.Status) {
+		return errors.ServiceUnavailable("WATCHER_TOO_SLOW", "watcher fell behind, watch the transaction again")
+	}
+	attempt := trx.RetryCount
+	if attempt == 0 {
+		attempt = 1
+	}
+	return send(NewTransactionEvent(trx, attempt, entity.LogEntry{
+		Timestamp: trx.UpdatedAt,
+		Message:   trx.ProcessDescription,
+		Status:    trx.Status,
+	}))
+}
+
+// Publish fans an encoded TransactionEvent out to the watchers of its transaction.
+func (w *Watcher) Publish(key, value []byte) {
+	var event v1.TransactionEvent
+	if err := protojson.Unmarshal(value, &event); err != nil {
+		w.log.Errorf("failed to unmarshal transaction event: %v", err)
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ch := range w.watchers[event.TransactionId] {
+		select {
+		case ch <- &event:
+		default:
+			// Closing tells the watcher it missed events.
+			w.log.Warnf("disconnecting slow watcher of transaction %s", event.TransactionId)
+			delete(w.watchers[event.TransactionId], ch)
+			close(ch)
+		}
+	}
+}
+
+func (w *Watcher) subscribe(transactionID string) chan *v1.TransactionEvent {
+	ch := make(chan *v1.TransactionEvent, watcherBuffer)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.watchers[transactionID] == nil {
+		w.watchers[transactionID] = make(map[chan *v1.TransactionEvent]struct{})
+	}
+	w.watchers[transactionID][ch] = struct{}{}
+	return ch
+}
+
+func (w *Watcher) unsubscribe(transactionID string, ch chan *v1.TransactionEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.watchers[transactionID], ch)
+	if len(w.watchers[transactionID]) == 0 {
+		delete(w.watchers, transactionID)
+	}
+}
+
+func isSettled(status string) bool {
+	return status == v1.TransactionStatus_SUCCESS.String() || status == v1.TransactionStatus_FAILED.String()
+}
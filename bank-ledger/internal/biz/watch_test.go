@@ -0,0 +1,100 @@
+package biz
+
+import (
+	"bank-ledger/internal/entity"
+	"context"
+	"testing"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// published is a log entry the consumer published for an attempt.
+type published struct {
+	attempt int
+	entry   entity.LogEntry
+}
+
+func TestWatchSkipsEventsAlreadyReplayed(t *testing.T) {
+	trx := &entity.Transaction{ID: "trx-1", AccountID: "acc-1", Status: v1.TransactionStatus_PROCESSING.String(), RetryCount: 1}
+	at := func(sec int) time.Time { return time.Date(2024, 3, 1, 10, 0, sec, 0, time.Local) }
+	initiated := entity.LogEntry{Timestamp: at(0), Message: "Transaction created", Status: "INITIATED"}
+	started := entity.LogEntry{Timestamp: at(1), Message: "Transaction processing started", Status: "PROCESSING"}
+	succeeded := entity.LogEntry{Timestamp: at(2), Message: "Transaction processed successfully", Status: "SUCCESS"}
+	// MongoDB hands entries back in UTC while the consumer published them
+	// in local time.
+	stored := func(entries ...entity.LogEntry) *entity.TransactionLog {
+		for i := range entries {
+			entries[i].Timestamp = entries[i].Timestamp.UTC()
+		}
+		return &entity.TransactionLog{TransactionID: trx.ID, TransactionLogs: []entity.TryLog{{Attempt: 1, Logs: entries}}}
+	}
+
+	tests := []struct {
+		name string
+		log  *entity.TransactionLog
+		// published arrive after the watcher subscribed but before the log is
+		// read.
+		published []published
+		want      []string
+	}{
+		{
+			name:      "logged and published",
+			log:       stored(initiated, started),
+			published: []published{{1, started}, {1, succeeded}},
+			want:      []string{"INITIATED", "PROCESSING", "SUCCESS"},
+		},
+		{
+			name:      "settled in the log",
+			log:       stored(initiated, started, succeeded),
+			published: []published{{1, succeeded}},
+			want:      []string{"INITIATED", "PROCESSING", "SUCCESS"},
+		},
+		{
+			name:      "same entry on another attempt",
+			log:       stored(initiated),
+			published: []published{{2, initiated}, {2, succeeded}},
+			want:      []string{"INITIATED", "INITIATED", "SUCCESS"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := &fakeLogs{log: tt.log}
+			watcher := NewTransactionWatcher(&fakeTransactions{transactions: []*entity.Transaction{trx}}, logs, log.DefaultLogger)
+			logs.onGet = func() {
+				for _, p := range tt.published {
+					value, err := protojson.Marshal(NewTransactionEvent(trx, p.attempt, p.entry))
+					if err != nil {
+						t.Fatalf("marshal: %v", err)
+					}
+					watcher.Publish([]byte(trx.ID), value)
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			var got []string
+			err := watcher.Watch(ctx, &v1.WatchTransactionRequest{TransactionId: trx.ID}, func(event *v1.TransactionEvent) error {
+				got = append(got, event.Status.String())
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Watch: %v", err)
+			}
+			if ctx.Err() != nil {
+				t.Fatal("Watch did not return once the transaction settled")
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("sent %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("sent %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,554 @@
+package biz
+
+import (
+	"bank-ledger/internal/conf"
+	"bank-ledger/internal/data"
+	"bank-ledger/internal/entity"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/rs/xid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	WebhookEventTransactionProcessing = "transaction.processing"
+	WebhookEventTransactionSucceeded  = "transaction.succeeded"
+	WebhookEventTransactionFailed     = "transaction.failed"
+	WebhookEventAccountCreated        = "account.created"
+	WebhookEventAccountUpdated        = "account.updated"
+	WebhookEventAccountClosed         = "account.closed"
+	WebhookEventBalanceMismatch       = "account.balance_mismatch"
+)
+
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+var webhookEventTypes = map[string]bool{
+	WebhookEventTransactionProcessing: true,
+	WebhookEventTransactionSucceeded:  true,
+	WebhookEventTransactionFailed:     true,
+	WebhookEventAccountCreated:        true,
+	WebhookEventAccountUpdated:        true,
+	WebhookEventAccountClosed:         true,
+	WebhookEventBalanceMismatch:       true,
+}
+
+// maxWebhookAccounts caps the accounts a subscription can cover.
+const maxWebhookAccounts = 50
+
+// webhookPayload is the JSON body posted to subscribers.
+type webhookPayload struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt string          `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type WebhookHandler interface {
+	Create(ctx context.Context, req *v1.CreateWebhookRequest) (*v1.WebhookResponse, error)
+	FindByID(ctx context.Context, req *v1.BaseRequest) (*v1.WebhookResponse, error)
+	List(ctx context.Context, req *v1.ListWebhooksRequest) (*v1.ListWebhooksResponse, error)
+	Delete(ctx context.Context, req *v1.BaseRequest) (*v1.WebhookResponse, error)
+	ListDeliveries(ctx context.Context, req *v1.ListWebhookDeliveriesRequest) (*v1.ListWebhookDeliveriesResponse, error)
+	Redeliver(ctx context.Context, req *v1.BaseRequest) (*v1.WebhookDelivery, error)
+	Enqueue(ctx context.Context, eventType string, payload proto.Message, accountIDs ...string) error
+	DeliverDue(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+type Webhook struct {
+	repo           data.WebhookRepository
+	client         *http.Client
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	allowPrivate   bool
+	log            *log.Helper
+}
+
+func NewWebhookHandler(c *conf.Server, repo data.WebhookRepository, logger log.Logger) WebhookHandler {
+	w := &Webhook{
+		repo:           repo,
+		maxAttempts:    8,
+		initialBackoff: 30 * time.Second,
+		maxBackoff:     6 * time.Hour,
+		allowPrivate:   c.GetWebhook().GetAllowPrivateTargets(),
+		log:            log.NewHelper(log.With(logger, "module", "biz/webhook")),
+	}
+	w.client = newWebhookClient(w.allowPrivate)
+	if c != nil && c.Webhook != nil {
+		if c.Webhook.MaxAttempts > 0 {
+			w.maxAttempts = int(c.Webhook.MaxAttempts)
+		}
+		if c.Webhook.InitialBackoff != nil {
+			w.initialBackoff = c.Webhook.InitialBackoff.AsDuration()
+		}
+		if c.Webhook.MaxBackoff != nil {
+			w.maxBackoff = c.Webhook.MaxBackoff.AsDuration()
+		}
+		if c.Webhook.Timeout != nil {
+			w.client.Timeout = c.Webhook.Timeout.AsDuration()
+		}
+	}
+	return w
+}
+
+func (w *Webhook) Create(ctx context.Context, req *v1.CreateWebhookRequest) (*v1.WebhookResponse, error) {
+	if req.ClientId == "" {
+		return nil, errors.BadRequest("CLIENT_ID_REQUIRED", "client_id is required")
+	}
+
+	u, err := url.Parse(req.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.BadRequest("INVALID_URL", "url must be an absolute http or https URL")
+	}
+	if !w.allowPrivate {
+		if err := checkWebhookHost(ctx, u.Hostname()); err != nil {
+			return nil, errors.BadRequest("INVALID_URL", err.Error())
+		}
+	}
+
+	if len(req.AccountIds) == 0 {
+		return nil, errors.BadRequest("ACCOUNT_IDS_REQUIRED", "at least one account id is required")
+	}
+	if len(req.AccountIds) > maxWebhookAccounts {
+		return nil, errors.BadRequest("TOO_MANY_ACCOUNTS", fmt.Sprintf("at most %d account ids are allowed", maxWebhookAccounts))
+	}
+	for _, id := range req.AccountIds {
+		if id == "" || strings.Contains(id, ",") {
+			return nil, errors.BadRequest("INVALID_ACCOUNT_ID", fmt.Sprintf("invalid account id: %q", id))
+		}
+	}
+
+	if len(req.EventTypes) == 0 {
+		return nil, errors.BadRequest("EVENT_TYPES_REQUIRED", "at least one event type is required")
+	}
+	for _, eventType := range req.EventTypes {
+		if !webhookEventTypes[eventType] {
+			return nil, errors.BadRequest("INVALID_EVENT_TYPE", fmt.Sprintf("unknown event type: %s", eventType))
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		raw := make([]byte, 32)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, errors.InternalServer("SECRET_ERROR", err.Error())
+		}
+		secret = hex.EncodeToString(raw)
+	}
+
+	subscription := &entity.WebhookSubscription{
+		ID:         xid.New().String(),
+		ClientID:   req.ClientId,
+		URL:        req.Url,
+		EventTypes: strings.Join(req.EventTypes, ","),
+		AccountIDs: strings.Join(req.AccountIds, ","),
+		Secret:     secret,
+		Active:     true,
+	}
+	if err := w.repo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+
+	resp := toProtoWebhook(subscription)
+	resp.Secret = secret
+	return resp, nil
+}
+
+func (w *Webhook) FindByID(ctx context.Context, req *v1.BaseRequest) (*v1.WebhookResponse, error) {
+	subscription, err := w.repo.FindSubscriptionByID(ctx, req)
+	if err != nil {
+		return nil, errors.NotFound("WEBHOOK_NOT_FOUND", "webhook not found")
+	}
+	return toProtoWebhook(subscription), nil
+}
+
+func (w *Webhook) List(ctx context.Context, req *v1.ListWebhooksRequest) (*v1.ListWebhooksResponse, error) {
+	subscriptions, err := w.repo.ListSubscriptions(ctx, req.ClientId)
+	if err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+
+	resp := &v1.ListWebhooksResponse{Webhooks: make([]*v1.WebhookResponse, 0, len(subscriptions))}
+	for _, subscription := range subscriptions {
+		resp.Webhooks = append(resp.Webhooks, toProtoWebhook(subscription))
+	}
+	return resp, nil
+}
+
+func (w *Webhook) Delete(ctx context.Context, req *v1.BaseRequest) (*v1.WebhookResponse, error) {
+	subscription, err := w.repo.FindSubscriptionByID(ctx, req)
+	if err != nil {
+		return nil, errors.NotFound("WEBHOOK_NOT_FOUND", "webhook not found")
+	}
+
+	subscription.Active = false
+	if err := w.repo.UpdateSubscription(ctx, subscription); err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+	return toProtoWebhook(subscription), nil
+}
+
+func (w *Webhook) ListDeliveries(ctx context.Context, req *v1.ListWebhookDeliveriesRequest) (*v1.ListWebhookDeliveriesResponse, error) {
+	if _, err := w.repo.FindSubscriptionByID(ctx, &v1.BaseRequest{Id: req.WebhookId}); err != nil {
+		return nil, errors.NotFound("WEBHOOK_NOT_FOUND", "webhook not found")
+	}
+
+	var status string
+	if req.Status != v1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED {
+		status = req.Status.String()
+	}
+
+	deliveries, err := w.repo.ListDeliveries(ctx, req.WebhookId, status, normalizePageSize(req.PageSize))
+	if err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+
+	ids := make([]string, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.ID)
+	}
+	attempts, err := w.repo.FindAttempts(ctx, ids)
+	if err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+	byDelivery := make(map[string][]*entity.WebhookAttempt)
+	for _, attempt := range attempts {
+		byDelivery[attempt.DeliveryID] = append(byDelivery[attempt.DeliveryID], attempt)
+	}
+
+	resp := &v1.ListWebhookDeliveriesResponse{Deliveries: make([]*v1.WebhookDelivery, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, toProtoWebhookDelivery(delivery, byDelivery[delivery.ID]))
+	}
+	return resp, nil
+}
+
+// Redeliver queues a delivery for immediate sending with a fresh retry budget.
+func (w *Webhook) Redeliver(ctx context.Context, req *v1.BaseRequest) (*v1.WebhookDelivery, error) {
+	delivery, err := w.repo.FindDeliveryByID(ctx, req)
+	if err != nil {
+		return nil, errors.NotFound("DELIVERY_NOT_FOUND", "webhook delivery not found")
+	}
+
+	subscription, err := w.repo.FindSubscriptionByID(ctx, &v1.BaseRequest{Id: delivery.SubscriptionID})
+	if err != nil || !subscription.Active {
+		return nil, errors.BadRequest("WEBHOOK_INACTIVE", "webhook is no longer active")
+	}
+
+	delivery.Status = v1.WebhookDeliveryStatus_DELIVERY_PENDING.String()
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	if err := w.repo.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+
+	attempts, err := w.repo.FindAttempts(ctx, []string{delivery.ID})
+	if err != nil {
+		return nil, errors.InternalServer("DB_ERROR", err.Error())
+	}
+	return toProtoWebhookDelivery(delivery, attempts), nil
+}
+
+// Enqueue records a pending delivery of the event for every active
+// subscription to its type that covers one of the accounts it concerns. The
+// delivery worker sends them.
+func (w *Webhook) Enqueue(ctx context.Context, eventType string, payload proto.Message, accountIDs ...string) error {
+	subscriptions, err := w.repo.ListActiveSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	var matching []*entity.WebhookSubscription
+	for _, subscription := range subscriptions {
+		if containsAny(subscription.EventTypes, eventType) && containsAny(subscription.AccountIDs, accountIDs...) {
+			matching = append(matching, subscription)
+		}
+	}
+	if len(matching) == 0 {
+		return nil
+	}
+
+	raw, err := protojson.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	now := time.Now()
+	eventID := xid.New().String()
+	body, err := json.Marshal(webhookPayload{
+		ID:        eventID,
+		Type:      eventType,
+		CreatedAt: now.Format(time.RFC3339),
+		Data:      raw,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	deliveries := make([]*entity.WebhookDelivery, 0, len(matching))
+	for _, subscription := range matching {
+		deliveries = append(deliveries, &entity.WebhookDelivery{
+			ID:             xid.New().String(),
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			EventType:      eventType,
+			Payload:        string(body),
+			Status:         v1.WebhookDeliveryStatus_DELIVERY_PENDING.String(),
+			NextAttemptAt:  now,
+		})
+	}
+	return w.repo.CreateDeliveries(ctx, deliveries)
+}
+
+// DeliverDue sends up to limit due deliveries and returns how many were attempted.
+func (w *Webhook) DeliverDue(ctx context.Context, now time.Time, limit int) (int, error) {
+	deliveries, err := w.repo.FindDueDeliveries(ctx, now, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find due webhook deliveries: %w", err)
+	}
+
+	subscriptions := make(map[string]*entity.WebhookSubscription)
+	processed := 0
+	for _, delivery := range deliveries {
+		// Hold the delivery for longer than a request can take so another
+		// worker does not send it concurrently.
+		claimed, err := w.repo.ClaimDelivery(ctx, delivery, now.Add(w.client.Timeout+30*time.Second))
+		if err != nil {
+			w.log.Errorf("failed to claim webhook delivery %s: %v", delivery.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = w.repo.FindSubscriptionByID(ctx, &v1.BaseRequest{Id: delivery.SubscriptionID})
+			if err != nil {
+				subscription = nil
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		if err := w.deliver(ctx, subscription, delivery); err != nil {
+			w.log.Errorf("failed to record webhook delivery %s: %v", delivery.ID, err)
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+func (w *Webhook) deliver(ctx context.Context, subscription *entity.WebhookSubscription, delivery *entity.WebhookDelivery) error {
+	if subscription == nil || !subscription.Active {
+		delivery.Status = v1.WebhookDeliveryStatus_DELIVERY_FAILED.String()
+		delivery.LastError = "webhook is no longer active"
+		return w.repo.UpdateDelivery(ctx, delivery)
+	}
+
+	delivery.Attempts++
+	start := time.Now()
+	statusCode, sendErr := w.send(ctx, subscription, delivery, start)
+	attempt := &entity.WebhookAttempt{
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts,
+		StatusCode: statusCode,
+		DurationMs: time.Since(start).Milliseconds(),
+		CreatedAt:  start,
+	}
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+	}
+	if err := w.repo.CreateAttempt(ctx, attempt); err != nil {
+		return err
+	}
+
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = attempt.Error
+	switch {
+	case sendErr == nil:
+		delivered := time.Now()
+		delivery.Status = v1.WebhookDeliveryStatus_DELIVERY_SUCCEEDED.String()
+		delivery.DeliveredAt = &delivered
+	case delivery.Attempts >= w.maxAttempts:
+		delivery.Status = v1.WebhookDeliveryStatus_DELIVERY_FAILED.String()
+		w.log.Warnf("webhook delivery %s failed after %d attempts: %v", delivery.ID, delivery.Attempts, sendErr)
+	default:
+		delivery.NextAttemptAt = time.Now().Add(w.backoff(delivery.Attempts))
+	}
+	return w.repo.UpdateDelivery(ctx, delivery)
+}
+
+func (w *Webhook) send(ctx context.Context, subscription *entity.WebhookSubscription, delivery *entity.WebhookDelivery, now time.Time) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID)
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(subscription.Secret, now, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// containsAny reports whether the comma-separated list holds any of values.
+func containsAny(list string, values ...string) bool {
+	for _, item := range strings.Split(list, ",") {
+		for _, v := range values {
+			if v != "" && item == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkWebhookHost rejects hosts that are or resolve to internal addresses, so
+// subscriptions cannot aim the delivery worker at services on our network.
+func checkWebhookHost(ctx context.Context, host string) error {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("url host %s does not resolve", host)
+	}
+	for _, addr := range addrs {
+		if isInternalIP(addr.IP) {
+			return fmt.Errorf("url host %s resolves to an internal address", host)
+		}
+	}
+	return nil
+}
+
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast()
+}
+
+// newWebhookClient returns the delivery client. Unless private targets are
+// allowed it refuses to connect to internal addresses, which also covers hosts
+// re-pointed after the subscription was created and redirects.
+func newWebhookClient(allowPrivate bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowPrivate {
+		transport.Proxy = nil
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				if ip := net.ParseIP(host); ip == nil || isInternalIP(ip) {
+					return fmt.Errorf("webhook target %s is an internal address", host)
+				}
+				return nil
+			},
+		}
+		transport.DialContext = dialer.DialContext
+	}
+	return &http.Client{Timeout: 10 * time.Second, Transport: transport}
+}
+
+func (w *Webhook) backoff(attempts int) time.Duration {
+	d := w.initialBackoff
+	for i := 1; i < attempts && d < w.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > w.maxBackoff {
+		d = w.maxBackoff
+	}
+	return d
+}
+
+// SignWebhook returns the signature header value for body: the timestamp and
+// the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the subscription secret.
+func SignWebhook(secret string, ts time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(ts.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func toProtoWebhook(subscription *entity.WebhookSubscription) *v1.WebhookResponse {
+	resp := &v1.WebhookResponse{
+		Id:         subscription.ID,
+		ClientId:   subscription.ClientID,
+		Url:        subscription.URL,
+		EventTypes: strings.Split(subscription.EventTypes, ","),
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  subscription.UpdatedAt.Format(time.RFC3339),
+	}
+	// Subscriptions created before account scoping have none and receive nothing.
+	if subscription.AccountIDs != "" {
+		resp.AccountIds = strings.Split(subscription.AccountIDs, ",")
+	}
+	return resp
+}
+
+func toProtoWebhookDelivery(delivery *entity.WebhookDelivery, attempts []*entity.WebhookAttempt) *v1.WebhookDelivery {
+	resp := &v1.WebhookDelivery{
+		Id:             delivery.ID,
+		WebhookId:      delivery.SubscriptionID,
+		EventId:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         v1.WebhookDeliveryStatus(v1.WebhookDeliveryStatus_value[delivery.Status]),
+		Attempts:       int32(delivery.Attempts),
+		LastStatusCode: int32(delivery.LastStatusCode),
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+	}
+	if delivery.Status == v1.WebhookDeliveryStatus_DELIVERY_PENDING.String() {
+		resp.NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+	}
+	if delivery.DeliveredAt != nil {
+		resp.DeliveredAt = delivery.DeliveredAt.Format(time.RFC3339)
+	}
+	for _, attempt := range attempts {
+		resp.AttemptLog = append(resp.AttemptLog, &v1.WebhookAttempt{
+			Attempt:    int32(attempt.Attempt),
+			StatusCode: int32(attempt.StatusCode),
+			Error:      attempt.Error,
+			DurationMs: attempt.DurationMs,
+			CreatedAt:  attempt.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return resp
+}
@@ -27,6 +27,8 @@ type Bootstrap struct {
 	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
 	Consumer      *Consumer              `protobuf:"bytes,2,opt,name=consumer,proto3" json:"consumer,omitempty"`
 	Data          *Data                  `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Fee           *Fee                   `protobuf:"bytes,4,opt,name=fee,proto3" json:"fee,omitempty"`
+	Tracing       *Tracing               `protobuf:"bytes,5,opt,name=tracing,proto3" json:"tracing,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,10 +84,30 @@ func (x *Bootstrap) GetData() *Data {
 	return nil
 }
 
+func (x *Bootstrap) GetFee() *Fee {
+	if x != nil {
+		return x.Fee
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetTracing() *Tracing {
+	if x != nil {
+		return x.Tracing
+	}
+	return nil
+}
+
 type Server struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Http          *Server_HTTP           `protobuf:"bytes,1,opt,name=http,proto3" json:"http,omitempty"`
 	Grpc          *Server_GRPC           `protobuf:"bytes,2,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	Scheduler     *Server_Worker         `protobuf:"bytes,3,opt,name=scheduler,proto3" json:"scheduler,omitempty"`
+	Batch         *Server_Worker         `protobuf:"bytes,4,opt,name=batch,proto3" json:"batch,omitempty"`
+	Webhook       *Server_Webhook        `protobuf:"bytes,5,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Outbox        *Server_Outbox         `protobuf:"bytes,6,opt,name=outbox,proto3" json:"outbox,omitempty"`
+	Sweeper       *Server_Sweeper        `protobuf:"bytes,7,opt,name=sweeper,proto3" json:"sweeper,omitempty"`
+	Snapshot      *Server_Snapshot       `protobuf:"bytes,8,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -134,10 +156,65 @@ func (x *Server) GetGrpc() *Server_GRPC {
 	return nil
 }
 
+func (x *Server) GetScheduler() *Server_Worker {
+	if x != nil {
+		return x.Scheduler
+	}
+	return nil
+}
+
+func (x *Server) GetBatch() *Server_Worker {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+func (x *Server) GetWebhook() *Server_Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *Server) GetOutbox() *Server_Outbox {
+	if x != nil {
+		return x.Outbox
+	}
+	return nil
+}
+
+func (x *Server) GetSweeper() *Server_Sweeper {
+	if x != nil {
+		return x.Sweeper
+	}
+	return nil
+}
+
+func (x *Server) GetSnapshot() *Server_Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
 type Consumer struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Http          *Consumer_HTTP         `protobuf:"bytes,1,opt,name=http,proto3" json:"http,omitempty"`
-	Grpc          *Consumer_GRPC         `protobuf:"bytes,2,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Http  *Consumer_HTTP         `protobuf:"bytes,1,opt,name=http,proto3" json:"http,omitempty"`
+	Grpc  *Consumer_GRPC         `protobuf:"bytes,2,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	// group is the Kafka consumer group, transaction-consumer-group by default.
+	Group string `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
+	// initial_offset is oldest or newest, used only while the group has no
+	// committed offset. Defaults to oldest so nothing published before the
+	// first start is skipped.
+	InitialOffset string `protobuf:"bytes,4,opt,name=initial_offset,json=initialOffset,proto3" json:"initial_offset,omitempty"`
+	// message_timeout bounds the processing of one message, 10s by default.
+	MessageTimeout *durationpb.Duration `protobuf:"bytes,5,opt,name=message_timeout,json=messageTimeout,proto3" json:"message_timeout,omitempty"`
+	// drain_timeout is how long shutdown waits for in-flight messages, 30s by default.
+	DrainTimeout *durationpb.Duration `protobuf:"bytes,6,opt,name=drain_timeout,json=drainTimeout,proto3" json:"drain_timeout,omitempty"`
+	// concurrency is how many messages of one partition are handled at once;
+	// messages for the same account still run in order. 1 by default.
+	Concurrency   int32 `protobuf:"varint,7,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -186,6 +263,41 @@ func (x *Consumer) GetGrpc() *Consumer_GRPC {
 	return nil
 }
 
+func (x *Consumer) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *Consumer) GetInitialOffset() string {
+	if x != nil {
+		return x.InitialOffset
+	}
+	return ""
+}
+
+func (x *Consumer) GetMessageTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.MessageTimeout
+	}
+	return nil
+}
+
+func (x *Consumer) GetDrainTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.DrainTimeout
+	}
+	return nil
+}
+
+func (x *Consumer) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
 type Data struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Database      *Data_Database         `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
@@ -254,29 +366,28 @@ func (x *Data) GetMongodb() *Data_MongoDB {
 	return nil
 }
 
-type Server_HTTP struct {
+type Fee struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
-	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Rules         []*Fee_Rule            `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	Interval      *durationpb.Duration   `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Server_HTTP) Reset() {
-	*x = Server_HTTP{}
+func (x *Fee) Reset() {
+	*x = Fee{}
 	mi := &file_conf_conf_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Server_HTTP) String() string {
+func (x *Fee) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Server_HTTP) ProtoMessage() {}
+func (*Fee) ProtoMessage() {}
 
-func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
+func (x *Fee) ProtoReflect() protoreflect.Message {
 	mi := &file_conf_conf_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -288,55 +399,54 @@ func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Server_HTTP.ProtoReflect.Descriptor instead.
-func (*Server_HTTP) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{1, 0}
-}
-
-func (x *Server_HTTP) GetNetwork() string {
-	if x != nil {
-		return x.Network
-	}
-	return ""
+// Deprecated: Use Fee.ProtoReflect.Descriptor instead.
+func (*Fee) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Server_HTTP) GetAddr() string {
+func (x *Fee) GetRules() []*Fee_Rule {
 	if x != nil {
-		return x.Addr
+		return x.Rules
 	}
-	return ""
+	return nil
 }
 
-func (x *Server_HTTP) GetTimeout() *durationpb.Duration {
+func (x *Fee) GetInterval() *durationpb.Duration {
 	if x != nil {
-		return x.Timeout
+		return x.Interval
 	}
 	return nil
 }
 
-type Server_GRPC struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
-	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+type Tracing struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// exporter is otlp, stdout, file or none. Tracing is off when empty.
+	Exporter string `protobuf:"bytes,1,opt,name=exporter,proto3" json:"exporter,omitempty"`
+	// endpoint is the OTLP gRPC collector address used by the otlp exporter.
+	Endpoint string `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Insecure bool   `protobuf:"varint,3,opt,name=insecure,proto3" json:"insecure,omitempty"`
+	// path is the file spans are appended to by the file exporter.
+	Path string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	// sample_ratio is the share of new traces recorded; 0 records all.
+	SampleRatio   float64 `protobuf:"fixed64,5,opt,name=sample_ratio,json=sampleRatio,proto3" json:"sample_ratio,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Server_GRPC) Reset() {
-	*x = Server_GRPC{}
+func (x *Tracing) Reset() {
+	*x = Tracing{}
 	mi := &file_conf_conf_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Server_GRPC) String() string {
+func (x *Tracing) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Server_GRPC) ProtoMessage() {}
+func (*Tracing) ProtoMessage() {}
 
-func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
+func (x *Tracing) ProtoReflect() protoreflect.Message {
 	mi := &file_conf_conf_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -348,33 +458,47 @@ func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Server_GRPC.ProtoReflect.Descriptor instead.
-func (*Server_GRPC) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{1, 1}
+// Deprecated: Use Tracing.ProtoReflect.Descriptor instead.
+func (*Tracing) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Server_GRPC) GetNetwork() string {
+func (x *Tracing) GetExporter() string {
 	if x != nil {
-		return x.Network
+		return x.Exporter
 	}
 	return ""
 }
 
-func (x *Server_GRPC) GetAddr() string {
+func (x *Tracing) GetEndpoint() string {
 	if x != nil {
-		return x.Addr
+		return x.Endpoint
 	}
 	return ""
 }
 
-func (x *Server_GRPC) GetTimeout() *durationpb.Duration {
+func (x *Tracing) GetInsecure() bool {
 	if x != nil {
-		return x.Timeout
+		return x.Insecure
 	}
-	return nil
+	return false
 }
 
-type Consumer_HTTP struct {
+func (x *Tracing) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Tracing) GetSampleRatio() float64 {
+	if x != nil {
+		return x.SampleRatio
+	}
+	return 0
+}
+
+type Server_HTTP struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
 	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
@@ -383,20 +507,20 @@ type Consumer_HTTP struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Consumer_HTTP) Reset() {
-	*x = Consumer_HTTP{}
+func (x *Server_HTTP) Reset() {
+	*x = Server_HTTP{}
 	mi := &file_conf_conf_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Consumer_HTTP) String() string {
+func (x *Server_HTTP) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Consumer_HTTP) ProtoMessage() {}
+func (*Server_HTTP) ProtoMessage() {}
 
-func (x *Consumer_HTTP) ProtoReflect() protoreflect.Message {
+func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
 	mi := &file_conf_conf_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -408,33 +532,33 @@ func (x *Consumer_HTTP) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Consumer_HTTP.ProtoReflect.Descriptor instead.
-func (*Consumer_HTTP) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{2, 0}
+// Deprecated: Use Server_HTTP.ProtoReflect.Descriptor instead.
+func (*Server_HTTP) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 0}
 }
 
-func (x *Consumer_HTTP) GetNetwork() string {
+func (x *Server_HTTP) GetNetwork() string {
 	if x != nil {
 		return x.Network
 	}
 	return ""
 }
 
-func (x *Consumer_HTTP) GetAddr() string {
+func (x *Server_HTTP) GetAddr() string {
 	if x != nil {
 		return x.Addr
 	}
 	return ""
 }
 
-func (x *Consumer_HTTP) GetTimeout() *durationpb.Duration {
+func (x *Server_HTTP) GetTimeout() *durationpb.Duration {
 	if x != nil {
 		return x.Timeout
 	}
 	return nil
 }
 
-type Consumer_GRPC struct {
+type Server_GRPC struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
 	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
@@ -443,20 +567,20 @@ type Consumer_GRPC struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Consumer_GRPC) Reset() {
-	*x = Consumer_GRPC{}
+func (x *Server_GRPC) Reset() {
+	*x = Server_GRPC{}
 	mi := &file_conf_conf_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Consumer_GRPC) String() string {
+func (x *Server_GRPC) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Consumer_GRPC) ProtoMessage() {}
+func (*Server_GRPC) ProtoMessage() {}
 
-func (x *Consumer_GRPC) ProtoReflect() protoreflect.Message {
+func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
 	mi := &file_conf_conf_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -468,54 +592,54 @@ func (x *Consumer_GRPC) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Consumer_GRPC.ProtoReflect.Descriptor instead.
-func (*Consumer_GRPC) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{2, 1}
+// Deprecated: Use Server_GRPC.ProtoReflect.Descriptor instead.
+func (*Server_GRPC) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 1}
 }
 
-func (x *Consumer_GRPC) GetNetwork() string {
+func (x *Server_GRPC) GetNetwork() string {
 	if x != nil {
 		return x.Network
 	}
 	return ""
 }
 
-func (x *Consumer_GRPC) GetAddr() string {
+func (x *Server_GRPC) GetAddr() string {
 	if x != nil {
 		return x.Addr
 	}
 	return ""
 }
 
-func (x *Consumer_GRPC) GetTimeout() *durationpb.Duration {
+func (x *Server_GRPC) GetTimeout() *durationpb.Duration {
 	if x != nil {
 		return x.Timeout
 	}
 	return nil
 }
 
-type Data_Database struct {
+type Server_Worker struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Driver        string                 `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
-	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Interval      *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	BatchSize     int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_Database) Reset() {
-	*x = Data_Database{}
+func (x *Server_Worker) Reset() {
+	*x = Server_Worker{}
 	mi := &file_conf_conf_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_Database) String() string {
+func (x *Server_Worker) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_Database) ProtoMessage() {}
+func (*Server_Worker) ProtoMessage() {}
 
-func (x *Data_Database) ProtoReflect() protoreflect.Message {
+func (x *Server_Worker) ProtoReflect() protoreflect.Message {
 	mi := &file_conf_conf_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -527,48 +651,54 @@ func (x *Data_Database) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_Database.ProtoReflect.Descriptor instead.
-func (*Data_Database) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{3, 0}
+// Deprecated: Use Server_Worker.ProtoReflect.Descriptor instead.
+func (*Server_Worker) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 2}
 }
 
-func (x *Data_Database) GetDriver() string {
+func (x *Server_Worker) GetInterval() *durationpb.Duration {
 	if x != nil {
-		return x.Driver
+		return x.Interval
 	}
-	return ""
+	return nil
 }
 
-func (x *Data_Database) GetSource() string {
+func (x *Server_Worker) GetBatchSize() int32 {
 	if x != nil {
-		return x.Source
+		return x.BatchSize
 	}
-	return ""
+	return 0
 }
 
-type Data_Redis struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Addr          string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
-	ReadTimeout   *durationpb.Duration   `protobuf:"bytes,2,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
-	WriteTimeout  *durationpb.Duration   `protobuf:"bytes,3,opt,name=write_timeout,json=writeTimeout,proto3" json:"write_timeout,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type Server_Webhook struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Interval       *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	BatchSize      int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	MaxAttempts    int32                  `protobuf:"varint,3,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	InitialBackoff *durationpb.Duration   `protobuf:"bytes,4,opt,name=initial_backoff,json=initialBackoff,proto3" json:"initial_backoff,omitempty"`
+	MaxBackoff     *durationpb.Duration   `protobuf:"bytes,5,opt,name=max_backoff,json=maxBackoff,proto3" json:"max_backoff,omitempty"`
+	Timeout        *durationpb.Duration   `protobuf:"bytes,6,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// allow_private_targets permits webhook URLs on loopback, private and
+	// link-local addresses, for local development only.
+	AllowPrivateTargets bool `protobuf:"varint,7,opt,name=allow_private_targets,json=allowPrivateTargets,proto3" json:"allow_private_targets,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *Data_Redis) Reset() {
-	*x = Data_Redis{}
+func (x *Server_Webhook) Reset() {
+	*x = Server_Webhook{}
 	mi := &file_conf_conf_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_Redis) String() string {
+func (x *Server_Webhook) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_Redis) ProtoMessage() {}
+func (*Server_Webhook) ProtoMessage() {}
 
-func (x *Data_Redis) ProtoReflect() protoreflect.Message {
+func (x *Server_Webhook) ProtoReflect() protoreflect.Message {
 	mi := &file_conf_conf_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -580,56 +710,92 @@ func (x *Data_Redis) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_Redis.ProtoReflect.Descriptor instead.
-func (*Data_Redis) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{3, 1}
+// Deprecated: Use Server_Webhook.ProtoReflect.Descriptor instead.
+func (*Server_Webhook) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 3}
 }
 
-func (x *Data_Redis) GetAddr() string {
+func (x *Server_Webhook) GetInterval() *durationpb.Duration {
 	if x != nil {
-		return x.Addr
+		return x.Interval
 	}
-	return ""
+	return nil
 }
 
-func (x *Data_Redis) GetReadTimeout() *durationpb.Duration {
+func (x *Server_Webhook) GetBatchSize() int32 {
 	if x != nil {
-		return x.ReadTimeout
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *Server_Webhook) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *Server_Webhook) GetInitialBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.InitialBackoff
 	}
 	return nil
 }
 
-func (x *Data_Redis) GetWriteTimeout() *durationpb.Duration {
+func (x *Server_Webhook) GetMaxBackoff() *durationpb.Duration {
 	if x != nil {
-		return x.WriteTimeout
+		return x.MaxBackoff
 	}
 	return nil
 }
 
-type Data_Kafka struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Brokers       []string               `protobuf:"bytes,1,rep,name=brokers,proto3" json:"brokers,omitempty"`
-	Retries       int32                  `protobuf:"varint,2,opt,name=retries,proto3" json:"retries,omitempty"`
-	RequiredAcks  int32                  `protobuf:"varint,3,opt,name=required_acks,json=requiredAcks,proto3" json:"required_acks,omitempty"`
-	Timeout       *durationpb.Duration   `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+func (x *Server_Webhook) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Server_Webhook) GetAllowPrivateTargets() bool {
+	if x != nil {
+		return x.AllowPrivateTargets
+	}
+	return false
+}
+
+type Server_Sweeper struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Interval  *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	BatchSize int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// initiated_after and processing_after are how long a transaction may
+	// stay INITIATED or PROCESSING before it is swept; 5m by default.
+	InitiatedAfter  *durationpb.Duration `protobuf:"bytes,3,opt,name=initiated_after,json=initiatedAfter,proto3" json:"initiated_after,omitempty"`
+	ProcessingAfter *durationpb.Duration `protobuf:"bytes,4,opt,name=processing_after,json=processingAfter,proto3" json:"processing_after,omitempty"`
+	// policy is "republish" (default) to send the command to the consumer
+	// again, or "fail" to mark the transaction FAILED.
+	Policy string `protobuf:"bytes,5,opt,name=policy,proto3" json:"policy,omitempty"`
+	// max_retries fails a transaction regardless of policy once the sweeper
+	// has republished it this many times; 5 by default.
+	MaxRetries    int32 `protobuf:"varint,6,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_Kafka) Reset() {
-	*x = Data_Kafka{}
+func (x *Server_Sweeper) Reset() {
+	*x = Server_Sweeper{}
 	mi := &file_conf_conf_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_Kafka) String() string {
+func (x *Server_Sweeper) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_Kafka) ProtoMessage() {}
+func (*Server_Sweeper) ProtoMessage() {}
 
-func (x *Data_Kafka) ProtoReflect() protoreflect.Message {
+func (x *Server_Sweeper) ProtoReflect() protoreflect.Message {
 	mi := &file_conf_conf_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -641,50 +807,513 @@ func (x *Data_Kafka) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_Kafka.ProtoReflect.Descriptor instead.
-func (*Data_Kafka) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{3, 2}
+// Deprecated: Use Server_Sweeper.ProtoReflect.Descriptor instead.
+func (*Server_Sweeper) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 4}
 }
 
-func (x *Data_Kafka) GetBrokers() []string {
+func (x *Server_Sweeper) GetInterval() *durationpb.Duration {
 	if x != nil {
-		return x.Brokers
+		return x.Interval
 	}
 	return nil
 }
 
-func (x *Data_Kafka) GetRetries() int32 {
+func (x *Server_Sweeper) GetBatchSize() int32 {
 	if x != nil {
-		return x.Retries
+		return x.BatchSize
 	}
 	return 0
 }
 
-func (x *Data_Kafka) GetRequiredAcks() int32 {
+func (x *Server_Sweeper) GetInitiatedAfter() *durationpb.Duration {
 	if x != nil {
-		return x.RequiredAcks
+		return x.InitiatedAfter
 	}
-	return 0
+	return nil
 }
 
-func (x *Data_Kafka) GetTimeout() *durationpb.Duration {
+func (x *Server_Sweeper) GetProcessingAfter() *durationpb.Duration {
 	if x != nil {
-		return x.Timeout
+		return x.ProcessingAfter
 	}
 	return nil
 }
 
-type Data_MongoDB struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
-	Database      string                 `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
+func (x *Server_Sweeper) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *Server_Sweeper) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+type Server_Outbox struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Interval  *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	BatchSize int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// retention is how long published events are kept before the relay
+	// deletes them; 168h by default.
+	Retention     *durationpb.Duration `protobuf:"bytes,3,opt,name=retention,proto3" json:"retention,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Outbox) Reset() {
+	*x = Server_Outbox{}
+	mi := &file_conf_conf_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Outbox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Outbox) ProtoMessage() {}
+
+func (x *Server_Outbox) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Outbox.ProtoReflect.Descriptor instead.
+func (*Server_Outbox) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 5}
+}
+
+func (x *Server_Outbox) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Server_Outbox) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *Server_Outbox) GetRetention() *durationpb.Duration {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+type Server_Snapshot struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Interval  *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	BatchSize int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// settle_after delays a day's snapshot past midnight so transactions
+	// created before midnight have been applied; 1h by default.
+	SettleAfter   *durationpb.Duration `protobuf:"bytes,3,opt,name=settle_after,json=settleAfter,proto3" json:"settle_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Snapshot) Reset() {
+	*x = Server_Snapshot{}
+	mi := &file_conf_conf_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Snapshot) ProtoMessage() {}
+
+func (x *Server_Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Snapshot.ProtoReflect.Descriptor instead.
+func (*Server_Snapshot) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 6}
+}
+
+func (x *Server_Snapshot) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Server_Snapshot) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *Server_Snapshot) GetSettleAfter() *durationpb.Duration {
+	if x != nil {
+		return x.SettleAfter
+	}
+	return nil
+}
+
+type Consumer_HTTP struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Consumer_HTTP) Reset() {
+	*x = Consumer_HTTP{}
+	mi := &file_conf_conf_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Consumer_HTTP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Consumer_HTTP) ProtoMessage() {}
+
+func (x *Consumer_HTTP) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Consumer_HTTP.ProtoReflect.Descriptor instead.
+func (*Consumer_HTTP) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *Consumer_HTTP) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Consumer_HTTP) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Consumer_HTTP) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type Consumer_GRPC struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Consumer_GRPC) Reset() {
+	*x = Consumer_GRPC{}
+	mi := &file_conf_conf_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Consumer_GRPC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Consumer_GRPC) ProtoMessage() {}
+
+func (x *Consumer_GRPC) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Consumer_GRPC.ProtoReflect.Descriptor instead.
+func (*Consumer_GRPC) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *Consumer_GRPC) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Consumer_GRPC) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Consumer_GRPC) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type Data_Database struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Driver        string                 `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Database) Reset() {
+	*x = Data_Database{}
+	mi := &file_conf_conf_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Database) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Database) ProtoMessage() {}
+
+func (x *Data_Database) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Database.ProtoReflect.Descriptor instead.
+func (*Data_Database) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *Data_Database) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *Data_Database) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type Data_Redis struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addr          string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	ReadTimeout   *durationpb.Duration   `protobuf:"bytes,2,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
+	WriteTimeout  *durationpb.Duration   `protobuf:"bytes,3,opt,name=write_timeout,json=writeTimeout,proto3" json:"write_timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Redis) Reset() {
+	*x = Data_Redis{}
+	mi := &file_conf_conf_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Redis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Redis) ProtoMessage() {}
+
+func (x *Data_Redis) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Redis.ProtoReflect.Descriptor instead.
+func (*Data_Redis) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *Data_Redis) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Data_Redis) GetReadTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ReadTimeout
+	}
+	return nil
+}
+
+func (x *Data_Redis) GetWriteTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.WriteTimeout
+	}
+	return nil
+}
+
+type Data_Kafka struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Brokers      []string               `protobuf:"bytes,1,rep,name=brokers,proto3" json:"brokers,omitempty"`
+	Retries      int32                  `protobuf:"varint,2,opt,name=retries,proto3" json:"retries,omitempty"`
+	RequiredAcks int32                  `protobuf:"varint,3,opt,name=required_acks,json=requiredAcks,proto3" json:"required_acks,omitempty"`
+	Timeout      *durationpb.Duration   `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// none, gzip, snappy, lz4 or zstd.
+	Compression   string             `protobuf:"bytes,5,opt,name=compression,proto3" json:"compression,omitempty"`
+	Async         *Data_Kafka_Async  `protobuf:"bytes,6,opt,name=async,proto3" json:"async,omitempty"`
+	Topics        *Data_Kafka_Topics `protobuf:"bytes,7,opt,name=topics,proto3" json:"topics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Kafka) Reset() {
+	*x = Data_Kafka{}
+	mi := &file_conf_conf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Kafka) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Kafka) ProtoMessage() {}
+
+func (x *Data_Kafka) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Kafka.ProtoReflect.Descriptor instead.
+func (*Data_Kafka) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 2}
+}
+
+func (x *Data_Kafka) GetBrokers() []string {
+	if x != nil {
+		return x.Brokers
+	}
+	return nil
+}
+
+func (x *Data_Kafka) GetRetries() int32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *Data_Kafka) GetRequiredAcks() int32 {
+	if x != nil {
+		return x.RequiredAcks
+	}
+	return 0
+}
+
+func (x *Data_Kafka) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Data_Kafka) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *Data_Kafka) GetAsync() *Data_Kafka_Async {
+	if x != nil {
+		return x.Async
+	}
+	return nil
+}
+
+func (x *Data_Kafka) GetTopics() *Data_Kafka_Topics {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+type Data_MongoDB struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Database      string                 `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
 func (x *Data_MongoDB) Reset() {
 	*x = Data_MongoDB{}
-	mi := &file_conf_conf_proto_msgTypes[11]
+	mi := &file_conf_conf_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -696,7 +1325,7 @@ func (x *Data_MongoDB) String() string {
 func (*Data_MongoDB) ProtoMessage() {}
 
 func (x *Data_MongoDB) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[11]
+	mi := &file_conf_conf_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -726,19 +1355,342 @@ func (x *Data_MongoDB) GetDatabase() string {
 	return ""
 }
 
+// Async batches sends in the background instead of blocking each caller
+// on broker acks; results are reported through delivery callbacks.
+type Data_Kafka_Async struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Enabled           bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	FlushMessages     int32                  `protobuf:"varint,2,opt,name=flush_messages,json=flushMessages,proto3" json:"flush_messages,omitempty"`
+	FlushFrequency    *durationpb.Duration   `protobuf:"bytes,3,opt,name=flush_frequency,json=flushFrequency,proto3" json:"flush_frequency,omitempty"`
+	FlushBytes        int32                  `protobuf:"varint,4,opt,name=flush_bytes,json=flushBytes,proto3" json:"flush_bytes,omitempty"`
+	ChannelBufferSize int32                  `protobuf:"varint,5,opt,name=channel_buffer_size,json=channelBufferSize,proto3" json:"channel_buffer_size,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Data_Kafka_Async) Reset() {
+	*x = Data_Kafka_Async{}
+	mi := &file_conf_conf_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Kafka_Async) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Kafka_Async) ProtoMessage() {}
+
+func (x *Data_Kafka_Async) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Kafka_Async.ProtoReflect.Descriptor instead.
+func (*Data_Kafka_Async) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 2, 0}
+}
+
+func (x *Data_Kafka_Async) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Data_Kafka_Async) GetFlushMessages() int32 {
+	if x != nil {
+		return x.FlushMessages
+	}
+	return 0
+}
+
+func (x *Data_Kafka_Async) GetFlushFrequency() *durationpb.Duration {
+	if x != nil {
+		return x.FlushFrequency
+	}
+	return nil
+}
+
+func (x *Data_Kafka_Async) GetFlushBytes() int32 {
+	if x != nil {
+		return x.FlushBytes
+	}
+	return 0
+}
+
+func (x *Data_Kafka_Async) GetChannelBufferSize() int32 {
+	if x != nil {
+		return x.ChannelBufferSize
+	}
+	return 0
+}
+
+// Topics left empty use the built-in names.
+type Data_Kafka_Topics struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Transactions           string                 `protobuf:"bytes,1,opt,name=transactions,proto3" json:"transactions,omitempty"`
+	TransactionsDeadLetter string                 `protobuf:"bytes,2,opt,name=transactions_dead_letter,json=transactionsDeadLetter,proto3" json:"transactions_dead_letter,omitempty"`
+	TransactionStatus      string                 `protobuf:"bytes,3,opt,name=transaction_status,json=transactionStatus,proto3" json:"transaction_status,omitempty"`
+	LedgerEvents           string                 `protobuf:"bytes,4,opt,name=ledger_events,json=ledgerEvents,proto3" json:"ledger_events,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Data_Kafka_Topics) Reset() {
+	*x = Data_Kafka_Topics{}
+	mi := &file_conf_conf_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Kafka_Topics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Kafka_Topics) ProtoMessage() {}
+
+func (x *Data_Kafka_Topics) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Kafka_Topics.ProtoReflect.Descriptor instead.
+func (*Data_Kafka_Topics) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 2, 1}
+}
+
+func (x *Data_Kafka_Topics) GetTransactions() string {
+	if x != nil {
+		return x.Transactions
+	}
+	return ""
+}
+
+func (x *Data_Kafka_Topics) GetTransactionsDeadLetter() string {
+	if x != nil {
+		return x.TransactionsDeadLetter
+	}
+	return ""
+}
+
+func (x *Data_Kafka_Topics) GetTransactionStatus() string {
+	if x != nil {
+		return x.TransactionStatus
+	}
+	return ""
+}
+
+func (x *Data_Kafka_Topics) GetLedgerEvents() string {
+	if x != nil {
+		return x.LedgerEvents
+	}
+	return ""
+}
+
+type Fee_Tier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpTo          float64                `protobuf:"fixed64,1,opt,name=up_to,json=upTo,proto3" json:"up_to,omitempty"`
+	Flat          float64                `protobuf:"fixed64,2,opt,name=flat,proto3" json:"flat,omitempty"`
+	Percentage    float64                `protobuf:"fixed64,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fee_Tier) Reset() {
+	*x = Fee_Tier{}
+	mi := &file_conf_conf_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fee_Tier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fee_Tier) ProtoMessage() {}
+
+func (x *Fee_Tier) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fee_Tier.ProtoReflect.Descriptor instead.
+func (*Fee_Tier) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *Fee_Tier) GetUpTo() float64 {
+	if x != nil {
+		return x.UpTo
+	}
+	return 0
+}
+
+func (x *Fee_Tier) GetFlat() float64 {
+	if x != nil {
+		return x.Flat
+	}
+	return 0
+}
+
+func (x *Fee_Tier) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+type Fee_Rule struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	TransactionType string                 `protobuf:"bytes,2,opt,name=transaction_type,json=transactionType,proto3" json:"transaction_type,omitempty"`
+	Kind            string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Flat            float64                `protobuf:"fixed64,4,opt,name=flat,proto3" json:"flat,omitempty"`
+	Percentage      float64                `protobuf:"fixed64,5,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	Tiers           []*Fee_Tier            `protobuf:"bytes,6,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	Min             float64                `protobuf:"fixed64,7,opt,name=min,proto3" json:"min,omitempty"`
+	Max             float64                `protobuf:"fixed64,8,opt,name=max,proto3" json:"max,omitempty"`
+	Period          string                 `protobuf:"bytes,9,opt,name=period,proto3" json:"period,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Fee_Rule) Reset() {
+	*x = Fee_Rule{}
+	mi := &file_conf_conf_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fee_Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fee_Rule) ProtoMessage() {}
+
+func (x *Fee_Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fee_Rule.ProtoReflect.Descriptor instead.
+func (*Fee_Rule) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *Fee_Rule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Fee_Rule) GetTransactionType() string {
+	if x != nil {
+		return x.TransactionType
+	}
+	return ""
+}
+
+func (x *Fee_Rule) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Fee_Rule) GetFlat() float64 {
+	if x != nil {
+		return x.Flat
+	}
+	return 0
+}
+
+func (x *Fee_Rule) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *Fee_Rule) GetTiers() []*Fee_Tier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+func (x *Fee_Rule) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *Fee_Rule) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *Fee_Rule) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
 var File_conf_conf_proto protoreflect.FileDescriptor
 
 const file_conf_conf_proto_rawDesc = "" +
 	"\n" +
 	"\x0fconf/conf.proto\x12\n" +
-	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\x8f\x01\n" +
+	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\xe1\x01\n" +
 	"\tBootstrap\x12*\n" +
 	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x120\n" +
 	"\bconsumer\x18\x02 \x01(\v2\x14.kratos.api.ConsumerR\bconsumer\x12$\n" +
-	"\x04data\x18\x03 \x01(\v2\x10.kratos.api.DataR\x04data\"\xb8\x02\n" +
+	"\x04data\x18\x03 \x01(\v2\x10.kratos.api.DataR\x04data\x12!\n" +
+	"\x03fee\x18\x04 \x01(\v2\x0f.kratos.api.FeeR\x03fee\x12-\n" +
+	"\atracing\x18\x05 \x01(\v2\x13.kratos.api.TracingR\atracing\"\xa8\r\n" +
 	"\x06Server\x12+\n" +
 	"\x04http\x18\x01 \x01(\v2\x17.kratos.api.Server.HTTPR\x04http\x12+\n" +
-	"\x04grpc\x18\x02 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x1ai\n" +
+	"\x04grpc\x18\x02 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x127\n" +
+	"\tscheduler\x18\x03 \x01(\v2\x19.kratos.api.Server.WorkerR\tscheduler\x12/\n" +
+	"\x05batch\x18\x04 \x01(\v2\x19.kratos.api.Server.WorkerR\x05batch\x124\n" +
+	"\awebhook\x18\x05 \x01(\v2\x1a.kratos.api.Server.WebhookR\awebhook\x121\n" +
+	"\x06outbox\x18\x06 \x01(\v2\x19.kratos.api.Server.OutboxR\x06outbox\x124\n" +
+	"\asweeper\x18\a \x01(\v2\x1a.kratos.api.Server.SweeperR\asweeper\x127\n" +
+	"\bsnapshot\x18\b \x01(\v2\x1b.kratos.api.Server.SnapshotR\bsnapshot\x1ai\n" +
 	"\x04HTTP\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
@@ -746,10 +1698,48 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\x04GRPC\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xbe\x02\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a^\n" +
+	"\x06Worker\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x1a\xeb\x02\n" +
+	"\aWebhook\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x12!\n" +
+	"\fmax_attempts\x18\x03 \x01(\x05R\vmaxAttempts\x12B\n" +
+	"\x0finitial_backoff\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x0einitialBackoff\x12:\n" +
+	"\vmax_backoff\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"maxBackoff\x123\n" +
+	"\atimeout\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x122\n" +
+	"\x15allow_private_targets\x18\a \x01(\bR\x13allowPrivateTargets\x1a\xa2\x02\n" +
+	"\aSweeper\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x12B\n" +
+	"\x0finitiated_after\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x0einitiatedAfter\x12D\n" +
+	"\x10processing_after\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x0fprocessingAfter\x12\x16\n" +
+	"\x06policy\x18\x05 \x01(\tR\x06policy\x12\x1f\n" +
+	"\vmax_retries\x18\x06 \x01(\x05R\n" +
+	"maxRetries\x1a\x97\x01\n" +
+	"\x06Outbox\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x127\n" +
+	"\tretention\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\tretention\x1a\x9e\x01\n" +
+	"\bSnapshot\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x12<\n" +
+	"\fsettle_after\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vsettleAfter\"\xa1\x04\n" +
 	"\bConsumer\x12-\n" +
 	"\x04http\x18\x01 \x01(\v2\x19.kratos.api.Consumer.HTTPR\x04http\x12-\n" +
-	"\x04grpc\x18\x02 \x01(\v2\x19.kratos.api.Consumer.GRPCR\x04grpc\x1ai\n" +
+	"\x04grpc\x18\x02 \x01(\v2\x19.kratos.api.Consumer.GRPCR\x04grpc\x12\x14\n" +
+	"\x05group\x18\x03 \x01(\tR\x05group\x12%\n" +
+	"\x0einitial_offset\x18\x04 \x01(\tR\rinitialOffset\x12B\n" +
+	"\x0fmessage_timeout\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x0emessageTimeout\x12>\n" +
+	"\rdrain_timeout\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\fdrainTimeout\x12 \n" +
+	"\vconcurrency\x18\a \x01(\x05R\vconcurrency\x1ai\n" +
 	"\x04HTTP\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
@@ -757,7 +1747,7 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\x04GRPC\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xf6\x04\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xa0\t\n" +
 	"\x04Data\x125\n" +
 	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseR\bdatabase\x12,\n" +
 	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x12,\n" +
@@ -769,15 +1759,57 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\x05Redis\x12\x12\n" +
 	"\x04addr\x18\x01 \x01(\tR\x04addr\x12<\n" +
 	"\fread_timeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\vreadTimeout\x12>\n" +
-	"\rwrite_timeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout\x1a\x95\x01\n" +
+	"\rwrite_timeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout\x1a\xbf\x05\n" +
 	"\x05Kafka\x12\x18\n" +
 	"\abrokers\x18\x01 \x03(\tR\abrokers\x12\x18\n" +
 	"\aretries\x18\x02 \x01(\x05R\aretries\x12#\n" +
 	"\rrequired_acks\x18\x03 \x01(\x05R\frequiredAcks\x123\n" +
-	"\atimeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a7\n" +
+	"\atimeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12 \n" +
+	"\vcompression\x18\x05 \x01(\tR\vcompression\x122\n" +
+	"\x05async\x18\x06 \x01(\v2\x1c.kratos.api.Data.Kafka.AsyncR\x05async\x125\n" +
+	"\x06topics\x18\a \x01(\v2\x1d.kratos.api.Data.Kafka.TopicsR\x06topics\x1a\xdd\x01\n" +
+	"\x05Async\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12%\n" +
+	"\x0eflush_messages\x18\x02 \x01(\x05R\rflushMessages\x12B\n" +
+	"\x0fflush_frequency\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x0eflushFrequency\x12\x1f\n" +
+	"\vflush_bytes\x18\x04 \x01(\x05R\n" +
+	"flushBytes\x12.\n" +
+	"\x13channel_buffer_size\x18\x05 \x01(\x05R\x11channelBufferSize\x1a\xba\x01\n" +
+	"\x06Topics\x12\"\n" +
+	"\ftransactions\x18\x01 \x01(\tR\ftransactions\x128\n" +
+	"\x18transactions_dead_letter\x18\x02 \x01(\tR\x16transactionsDeadLetter\x12-\n" +
+	"\x12transaction_status\x18\x03 \x01(\tR\x11transactionStatus\x12#\n" +
+	"\rledger_events\x18\x04 \x01(\tR\fledgerEvents\x1a7\n" +
 	"\aMongoDB\x12\x10\n" +
 	"\x03uri\x18\x01 \x01(\tR\x03uri\x12\x1a\n" +
-	"\bdatabase\x18\x02 \x01(\tR\bdatabaseB(Z&bank-ledger-service/internal/conf;confb\x06proto3"
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\"\xb1\x03\n" +
+	"\x03Fee\x12*\n" +
+	"\x05rules\x18\x01 \x03(\v2\x14.kratos.api.Fee.RuleR\x05rules\x125\n" +
+	"\binterval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\binterval\x1aO\n" +
+	"\x04Tier\x12\x13\n" +
+	"\x05up_to\x18\x01 \x01(\x01R\x04upTo\x12\x12\n" +
+	"\x04flat\x18\x02 \x01(\x01R\x04flat\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x03 \x01(\x01R\n" +
+	"percentage\x1a\xf5\x01\n" +
+	"\x04Rule\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12)\n" +
+	"\x10transaction_type\x18\x02 \x01(\tR\x0ftransactionType\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04flat\x18\x04 \x01(\x01R\x04flat\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x05 \x01(\x01R\n" +
+	"percentage\x12*\n" +
+	"\x05tiers\x18\x06 \x03(\v2\x14.kratos.api.Fee.TierR\x05tiers\x12\x10\n" +
+	"\x03min\x18\a \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\b \x01(\x01R\x03max\x12\x16\n" +
+	"\x06period\x18\t \x01(\tR\x06period\"\x94\x01\n" +
+	"\aTracing\x12\x1a\n" +
+	"\bexporter\x18\x01 \x01(\tR\bexporter\x12\x1a\n" +
+	"\bendpoint\x18\x02 \x01(\tR\bendpoint\x12\x1a\n" +
+	"\binsecure\x18\x03 \x01(\bR\binsecure\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12!\n" +
+	"\fsample_ratio\x18\x05 \x01(\x01R\vsampleRatioB(Z&bank-ledger-service/internal/conf;confb\x06proto3"
 
 var (
 	file_conf_conf_proto_rawDescOnce sync.Once
@@ -791,46 +1823,85 @@ func file_conf_conf_proto_rawDescGZIP() []byte {
 	return file_conf_conf_proto_rawDescData
 }
 
-var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_conf_conf_proto_goTypes = []any{
 	(*Bootstrap)(nil),           // 0: kratos.api.Bootstrap
 	(*Server)(nil),              // 1: kratos.api.Server
 	(*Consumer)(nil),            // 2: kratos.api.Consumer
 	(*Data)(nil),                // 3: kratos.api.Data
-	(*Server_HTTP)(nil),         // 4: kratos.api.Server.HTTP
-	(*Server_GRPC)(nil),         // 5: kratos.api.Server.GRPC
-	(*Consumer_HTTP)(nil),       // 6: kratos.api.Consumer.HTTP
-	(*Consumer_GRPC)(nil),       // 7: kratos.api.Consumer.GRPC
-	(*Data_Database)(nil),       // 8: kratos.api.Data.Database
-	(*Data_Redis)(nil),          // 9: kratos.api.Data.Redis
-	(*Data_Kafka)(nil),          // 10: kratos.api.Data.Kafka
-	(*Data_MongoDB)(nil),        // 11: kratos.api.Data.MongoDB
-	(*durationpb.Duration)(nil), // 12: google.protobuf.Duration
+	(*Fee)(nil),                 // 4: kratos.api.Fee
+	(*Tracing)(nil),             // 5: kratos.api.Tracing
+	(*Server_HTTP)(nil),         // 6: kratos.api.Server.HTTP
+	(*Server_GRPC)(nil),         // 7: kratos.api.Server.GRPC
+	(*Server_Worker)(nil),       // 8: kratos.api.Server.Worker
+	(*Server_Webhook)(nil),      // 9: kratos.api.Server.Webhook
+	(*Server_Sweeper)(nil),      // 10: kratos.api.Server.Sweeper
+	(*Server_Outbox)(nil),       // 11: kratos.api.Server.Outbox
+	(*Server_Snapshot)(nil),     // 12: kratos.api.Server.Snapshot
+	(*Consumer_HTTP)(nil),       // 13: kratos.api.Consumer.HTTP
+	(*Consumer_GRPC)(nil),       // 14: kratos.api.Consumer.GRPC
+	(*Data_Database)(nil),       // 15: kratos.api.Data.Database
+	(*Data_Redis)(nil),          // 16: kratos.api.Data.Redis
+	(*Data_Kafka)(nil),          // 17: kratos.api.Data.Kafka
+	(*Data_MongoDB)(nil),        // 18: kratos.api.Data.MongoDB
+	(*Data_Kafka_Async)(nil),    // 19: kratos.api.Data.Kafka.Async
+	(*Data_Kafka_Topics)(nil),   // 20: kratos.api.Data.Kafka.Topics
+	(*Fee_Tier)(nil),            // 21: kratos.api.Fee.Tier
+	(*Fee_Rule)(nil),            // 22: kratos.api.Fee.Rule
+	(*durationpb.Duration)(nil), // 23: google.protobuf.Duration
 }
 var file_conf_conf_proto_depIdxs = []int32{
 	1,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
 	2,  // 1: kratos.api.Bootstrap.consumer:type_name -> kratos.api.Consumer
 	3,  // 2: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
-	4,  // 3: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
-	5,  // 4: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
-	6,  // 5: kratos.api.Consumer.http:type_name -> kratos.api.Consumer.HTTP
-	7,  // 6: kratos.api.Consumer.grpc:type_name -> kratos.api.Consumer.GRPC
-	8,  // 7: kratos.api.Data.database:type_name -> kratos.api.Data.Database
-	9,  // 8: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
-	10, // 9: kratos.api.Data.kafka:type_name -> kratos.api.Data.Kafka
-	11, // 10: kratos.api.Data.mongodb:type_name -> kratos.api.Data.MongoDB
-	12, // 11: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
-	12, // 12: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	12, // 13: kratos.api.Consumer.HTTP.timeout:type_name -> google.protobuf.Duration
-	12, // 14: kratos.api.Consumer.GRPC.timeout:type_name -> google.protobuf.Duration
-	12, // 15: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
-	12, // 16: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
-	12, // 17: kratos.api.Data.Kafka.timeout:type_name -> google.protobuf.Duration
-	18, // [18:18] is the sub-list for method output_type
-	18, // [18:18] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	4,  // 3: kratos.api.Bootstrap.fee:type_name -> kratos.api.Fee
+	5,  // 4: kratos.api.Bootstrap.tracing:type_name -> kratos.api.Tracing
+	6,  // 5: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
+	7,  // 6: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	8,  // 7: kratos.api.Server.scheduler:type_name -> kratos.api.Server.Worker
+	8,  // 8: kratos.api.Server.batch:type_name -> kratos.api.Server.Worker
+	9,  // 9: kratos.api.Server.webhook:type_name -> kratos.api.Server.Webhook
+	11, // 10: kratos.api.Server.outbox:type_name -> kratos.api.Server.Outbox
+	10, // 11: kratos.api.Server.sweeper:type_name -> kratos.api.Server.Sweeper
+	12, // 12: kratos.api.Server.snapshot:type_name -> kratos.api.Server.Snapshot
+	13, // 13: kratos.api.Consumer.http:type_name -> kratos.api.Consumer.HTTP
+	14, // 14: kratos.api.Consumer.grpc:type_name -> kratos.api.Consumer.GRPC
+	23, // 15: kratos.api.Consumer.message_timeout:type_name -> google.protobuf.Duration
+	23, // 16: kratos.api.Consumer.drain_timeout:type_name -> google.protobuf.Duration
+	15, // 17: kratos.api.Data.database:type_name -> kratos.api.Data.Database
+	16, // 18: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
+	17, // 19: kratos.api.Data.kafka:type_name -> kratos.api.Data.Kafka
+	18, // 20: kratos.api.Data.mongodb:type_name -> kratos.api.Data.MongoDB
+	22, // 21: kratos.api.Fee.rules:type_name -> kratos.api.Fee.Rule
+	23, // 22: kratos.api.Fee.interval:type_name -> google.protobuf.Duration
+	23, // 23: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
+	23, // 24: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	23, // 25: kratos.api.Server.Worker.interval:type_name -> google.protobuf.Duration
+	23, // 26: kratos.api.Server.Webhook.interval:type_name -> google.protobuf.Duration
+	23, // 27: kratos.api.Server.Webhook.initial_backoff:type_name -> google.protobuf.Duration
+	23, // 28: kratos.api.Server.Webhook.max_backoff:type_name -> google.protobuf.Duration
+	23, // 29: kratos.api.Server.Webhook.timeout:type_name -> google.protobuf.Duration
+	23, // 30: kratos.api.Server.Sweeper.interval:type_name -> google.protobuf.Duration
+	23, // 31: kratos.api.Server.Sweeper.initiated_after:type_name -> google.protobuf.Duration
+	23, // 32: kratos.api.Server.Sweeper.processing_after:type_name -> google.protobuf.Duration
+	23, // 33: kratos.api.Server.Outbox.interval:type_name -> google.protobuf.Duration
+	23, // 34: kratos.api.Server.Outbox.retention:type_name -> google.protobuf.Duration
+	23, // 35: kratos.api.Server.Snapshot.interval:type_name -> google.protobuf.Duration
+	23, // 36: kratos.api.Server.Snapshot.settle_after:type_name -> google.protobuf.Duration
+	23, // 37: kratos.api.Consumer.HTTP.timeout:type_name -> google.protobuf.Duration
+	23, // 38: kratos.api.Consumer.GRPC.timeout:type_name -> google.protobuf.Duration
+	23, // 39: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
+	23, // 40: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
+	23, // 41: kratos.api.Data.Kafka.timeout:type_name -> google.protobuf.Duration
+	19, // 42: kratos.api.Data.Kafka.async:type_name -> kratos.api.Data.Kafka.Async
+	20, // 43: kratos.api.Data.Kafka.topics:type_name -> kratos.api.Data.Kafka.Topics
+	23, // 44: kratos.api.Data.Kafka.Async.flush_frequency:type_name -> google.protobuf.Duration
+	21, // 45: kratos.api.Fee.Rule.tiers:type_name -> kratos.api.Fee.Tier
+	46, // [46:46] is the sub-list for method output_type
+	46, // [46:46] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_conf_conf_proto_init() }
@@ -844,7 +1915,7 @@ func file_conf_conf_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conf_conf_proto_rawDesc), len(file_conf_conf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
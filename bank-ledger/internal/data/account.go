@@ -4,18 +4,47 @@ import (
 	v1 "bank-ledger/api/bankLedger/v1"
 	"bank-ledger/internal/entity"
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// AccountFilter narrows an account listing. Zero values are ignored.
+type AccountFilter struct {
+	Statuses    []string
+	Currency    string
+	NamePrefix  string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+// AccountSort orders an account listing by one of created_at, name or balance,
+// with id as the tie-breaker.
+type AccountSort struct {
+	Field string
+	Desc  bool
+}
+
+// AccountCursor is the sort value and id of the last account of a page.
+type AccountCursor struct {
+	Value interface{}
+	ID    string
+}
+
 type AccountRepository interface {
 	Create(ctx context.Context, req *entity.Account) error
 	Update(ctx context.Context, req *entity.Account) error
 	FindByID(ctx context.Context, req *v1.BaseRequest) (*entity.Account, error)
+	FindByIDForUpdate(ctx context.Context, id string) (*entity.Account, error)
+	FindByAccountNumber(ctx context.Context, accountNumber string) (*entity.Account, error)
 	ListAll(ctx context.Context) ([]*entity.Account, error)
+	FindPage(ctx context.Context, filter AccountFilter, sort AccountSort, after *AccountCursor, limit int) ([]*entity.Account, error)
 	Delete(ctx context.Context, req *v1.BaseRequest) error
+	CountByStatus(ctx context.Context, status string) (int64, error)
+	UpdateStatus(ctx context.Context, id string, status string) error
 	WithTx(tx *gorm.DB) AccountRepository
 }
 
@@ -64,6 +93,28 @@ func (r *AccountRepo) FindByID(ctx context.Context, req *v1.BaseRequest) (*entit
 	return &account, nil
 }
 
+// FindByIDForUpdate reads the account and locks its row until the surrounding
+// transaction ends, so a balance read and written back cannot lose a
+// concurrent change. Call it on a repository bound with WithTx.
+func (r *AccountRepo) FindByIDForUpdate(ctx context.Context, id string) (*entity.Account, error) {
+	var account entity.Account
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&account, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *AccountRepo) FindByAccountNumber(ctx context.Context, accountNumber string) (*entity.Account, error) {
+	var account entity.Account
+	if err := r.db.WithContext(ctx).First(&account, "account_number = ?", accountNumber).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
 func (r *AccountRepo) ListAll(ctx context.Context) ([]*entity.Account, error) {
 	var accounts []*entity.Account
 	if err := r.db.WithContext(ctx).Find(&accounts).Error; err != nil {
@@ -72,6 +123,64 @@ func (r *AccountRepo) ListAll(ctx context.Context) ([]*entity.Account, error) {
 	return accounts, nil
 }
 
+func (r *AccountRepo) FindPage(ctx context.Context, filter AccountFilter, sort AccountSort, after *AccountCursor, limit int) ([]*entity.Account, error) {
+	var accounts []*entity.Account
+
+	switch sort.Field {
+	case "created_at", "name", "balance":
+	default:
+		return nil, fmt.Errorf("unsupported sort field: %s", sort.Field)
+	}
+
+	query := r.db.WithContext(ctx).Model(&entity.Account{})
+
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.Currency != "" {
+		query = query.Where("currency = ?", filter.Currency)
+	}
+	if filter.NamePrefix != "" {
+		query = query.Where("name LIKE ?", escapeLike(filter.NamePrefix)+"%")
+	}
+	if !filter.CreatedFrom.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedTo)
+	}
+
+	direction, cmp := "ASC", ">"
+	if sort.Desc {
+		direction, cmp = "DESC", "<"
+	}
+	if after != nil {
+		query = query.Where(fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", sort.Field, cmp), after.Value, after.Value, after.ID)
+	}
+
+	order := fmt.Sprintf("%[1]s %[2]s, id %[2]s", sort.Field, direction)
+	if err := query.Order(order).Limit(limit).Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
 func (r *AccountRepo) Delete(ctx context.Context, req *v1.BaseRequest) error {
 	return r.db.WithContext(ctx).Delete(&entity.Account{}, "id = ?", req.Id).Error
 }
+
+func (r *AccountRepo) CountByStatus(ctx context.Context, status string) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Account{}).Where("status = ?", status).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// UpdateStatus changes only the account status, leaving a balance updated
+// concurrently by the consumer untouched.
+func (r *AccountRepo) UpdateStatus(ctx context.Context, id string, status string) error {
+	return r.db.WithContext(ctx).Model(&entity.Account{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"status": status, "updated_at": time.Now()}).Error
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	v1 "bank-ledger/api/bankLedger/v1"
+	"bank-ledger/internal/entity"
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+type BatchRepository interface {
+	Create(ctx context.Context, batch *entity.Batch, lines []*entity.BatchLine) error
+	Update(ctx context.Context, batch *entity.Batch) error
+	UpdateLine(ctx context.Context, line *entity.BatchLine) error
+	FindByID(ctx context.Context, req *v1.BaseRequest) (*entity.Batch, error)
+	FindByStatus(ctx context.Context, statuses []string, limit int) ([]*entity.Batch, error)
+	FindLines(ctx context.Context, batchID string) ([]*entity.BatchLine, error)
+	FindPendingLines(ctx context.Context, batchID string, limit int) ([]*entity.BatchLine, error)
+	WithTx(tx *gorm.DB) BatchRepository
+}
+
+type BatchRepo struct {
+	data *Data
+	db   *gorm.DB
+	log  *log.Helper
+}
+
+func NewBatchRepo(data *Data, logger log.Logger) BatchRepository {
+	return &BatchRepo{
+		data: data,
+		db:   data.db,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *BatchRepo) WithTx(tx *gorm.DB) BatchRepository {
+	return &BatchRepo{
+		data: r.data,
+		db:   tx,
+		log:  r.log,
+	}
+}
+
+func (r *BatchRepo) Create(ctx context.Context, batch *entity.Batch, lines []*entity.BatchLine) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(batch).Error; err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(lines, 500).Error
+	})
+}
+
+func (r *BatchRepo) Update(ctx context.Context, batch *entity.Batch) error {
+	batch.UpdatedAt = time.Now()
+	if err := r.db.WithContext(ctx).Save(batch).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *BatchRepo) UpdateLine(ctx context.Context, line *entity.BatchLine) error {
+	line.UpdatedAt = time.Now()
+	if err := r.db.WithContext(ctx).Save(line).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *BatchRepo) FindByID(ctx context.Context, req *v1.BaseRequest) (*entity.Batch, error) {
+	var batch entity.Batch
+	if err := r.db.WithContext(ctx).First(&batch, "id = ?", req.Id).Error; err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+func (r *BatchRepo) FindByStatus(ctx context.Context, statuses []string, limit int) ([]*entity.Batch, error) {
+	var batches []*entity.Batch
+	if err := r.db.WithContext(ctx).Where("status IN ?", statuses).Order("created_at ASC").Limit(limit).Find(&batches).Error; err != nil {
+		return nil, err
+	}
+	return batches, nil
+}
+
+func (r *BatchRepo) FindLines(ctx context.Context, batchID string) ([]*entity.BatchLine, error) {
+	var lines []*entity.BatchLine
+	if err := r.db.WithContext(ctx).Where("batch_id = ?", batchID).Order("line_number ASC").Find(&lines).Error; err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func (r *BatchRepo) FindPendingLines(ctx context.Context, batchID string, limit int) ([]*entity.BatchLine, error) {
+	var lines []*entity.BatchLine
+	err := r.db.WithContext(ctx).
+		Where("batch_id = ? AND status = ?", batchID, v1.BatchLineStatus_LINE_PENDING.String()).
+		Order("line_number ASC").
+		Limit(limit).
+		Find(&lines).Error
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
@@ -3,6 +3,8 @@ package data
 import (
 	"bank-ledger/internal/conf"
 	"bank-ledger/internal/entity"
+	"bank-ledger/internal/telemetry"
+	"context"
 	"fmt"
 
 	"gorm.io/driver/mysql"
@@ -12,7 +14,7 @@ import (
 	"github.com/google/wire"
 )
 
-var ProviderSet = wire.NewSet(NewData, NewMongoDBConnection, NewAccountRepo, NewTransactionRepo, NewTransactionLogsRepo)
+var ProviderSet = wire.NewSet(NewData, NewMongoDBConnection, NewAccountRepo, NewTransactionRepo, NewTransactionLogsRepo, NewScheduleRepo, NewBatchRepo, NewWebhookRepo, NewOutboxRepo, NewSequenceRepo, NewInboxRepo, NewSnapshotRepo)
 
 type Data struct {
 	db  *gorm.DB
@@ -31,7 +33,11 @@ func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
 			return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
 		}
 
-		err = db.AutoMigrate(&entity.Account{}, &entity.Transaction{})
+		if err = db.Use(telemetry.GormPlugin()); err != nil {
+			return nil, nil, fmt.Errorf("failed to instrument database: %w", err)
+		}
+
+		err = db.AutoMigrate(&entity.Account{}, &entity.Transaction{}, &entity.Schedule{}, &entity.Batch{}, &entity.BatchLine{}, &entity.WebhookSubscription{}, &entity.WebhookDelivery{}, &entity.WebhookAttempt{}, &entity.OutboxEvent{}, &entity.AccountSequence{}, &entity.ProcessedMessage{}, &entity.BalanceSnapshot{})
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to auto-migrate: %w", err)
 		}
@@ -50,6 +56,21 @@ func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
 	return &Data{db: db, log: helper}, cleanup, nil
 }
 
+// NewDataFromDB wraps an already open connection without migrating it, for
+// callers that manage the database themselves, such as tests.
+func NewDataFromDB(db *gorm.DB, logger log.Logger) *Data {
+	return &Data{db: db, log: log.NewHelper(logger)}
+}
+
 func (d *Data) DB() *gorm.DB {
 	return d.db
 }
+
+// Ping checks the database connection.
+func (d *Data) Ping(ctx context.Context) error {
+	sqlDB, err := d.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,60 @@
+package data
+
+import (
+	"bank-ledger/internal/entity"
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InboxRepository interface {
+	Create(ctx context.Context, msg *entity.ProcessedMessage) error
+	Processed(ctx context.Context, transactionID string) (bool, error)
+	WithTx(tx *gorm.DB) InboxRepository
+}
+
+type InboxRepo struct {
+	data *Data
+	db   *gorm.DB
+	log  *log.Helper
+}
+
+func NewInboxRepo(data *Data, logger log.Logger) InboxRepository {
+	return &InboxRepo{
+		data: data,
+		db:   data.db,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *InboxRepo) WithTx(tx *gorm.DB) InboxRepository {
+	return &InboxRepo{
+		data: r.data,
+		db:   tx,
+		log:  r.log,
+	}
+}
+
+func (r *InboxRepo) Create(ctx context.Context, msg *entity.ProcessedMessage) error {
+	if err := r.db.WithContext(ctx).Create(msg).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// Processed reports whether the transaction's command has been applied. Inside
+// a transaction the read takes a shared lock, so a concurrent commit of the
+// same command is waited for rather than missed.
+func (r *InboxRepo) Processed(ctx context.Context, transactionID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.ProcessedMessage{}).
+		Clauses(clause.Locking{Strength: "SHARE"}).
+		Where("transaction_id = ?", transactionID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -2,6 +2,7 @@ package data
 
 import (
 	"bank-ledger/internal/conf"
+	"bank-ledger/internal/telemetry"
 	"context"
 	"fmt"
 	"github.com/go-kratos/kratos/v2/log"
@@ -13,7 +14,7 @@ func NewMongoDBConnection(c *conf.Data, logger log.Logger) (*mongo.Database, fun
 	helper := log.NewHelper(logger)
 	mongoURI := c.Mongodb.Uri
 	dbName := c.Mongodb.Database
-	clientOptions := options.Client().ApplyURI(mongoURI)
+	clientOptions := options.Client().ApplyURI(mongoURI).SetMonitor(telemetry.MongoMonitor())
 	client, err := mongo.Connect(context.Background(), clientOptions)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
@@ -33,3 +34,8 @@ func NewMongoDBConnection(c *conf.Data, logger log.Logger) (*mongo.Database, fun
 
 	return database, cleanup, nil
 }
+
+// PingMongo checks the MongoDB connection.
+func PingMongo(ctx context.Context, db *mongo.Database) error {
+	return db.Client().Ping(ctx, nil)
+}
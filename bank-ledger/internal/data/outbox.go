@@ -0,0 +1,117 @@
+package data
+
+import (
+	"bank-ledger/internal/entity"
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository interface {
+	Create(ctx context.Context, events ...*entity.OutboxEvent) error
+	Update(ctx context.Context, req *entity.OutboxEvent) error
+	LockRelay(ctx context.Context) (bool, error)
+	UnlockRelay(ctx context.Context) error
+	LockPending(ctx context.Context, limit int) ([]*entity.OutboxEvent, error)
+	MarkPublished(ctx context.Context, ids []string, at time.Time) error
+	DeletePublishedBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	WithTx(tx *gorm.DB) OutboxRepository
+}
+
+type OutboxRepo struct {
+	data *Data
+	db   *gorm.DB
+	log  *log.Helper
+}
+
+func NewOutboxRepo(data *Data, logger log.Logger) OutboxRepository {
+	return &OutboxRepo{
+		data: data,
+		db:   data.db,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *OutboxRepo) WithTx(tx *gorm.DB) OutboxRepository {
+	return &OutboxRepo{
+		data: r.data,
+		db:   tx,
+		log:  r.log,
+	}
+}
+
+func (r *OutboxRepo) Create(ctx context.Context, events ...*entity.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(events).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *OutboxRepo) Update(ctx context.Context, req *entity.OutboxEvent) error {
+	if err := r.db.WithContext(ctx).Save(req).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// outboxRelayLock names the MySQL user lock held by the one relay publishing.
+const outboxRelayLock = "bank-ledger.outbox-relay"
+
+// LockRelay takes the relay lock without waiting and reports whether it got
+// it. The lock belongs to the connection, so it must run inside a transaction
+// and be released with UnlockRelay on the same one.
+func (r *OutboxRepo) LockRelay(ctx context.Context) (bool, error) {
+	var locked int
+	if err := r.db.WithContext(ctx).Raw("SELECT COALESCE(GET_LOCK(?, 0), 0)", outboxRelayLock).Scan(&locked).Error; err != nil {
+		return false, err
+	}
+	return locked == 1, nil
+}
+
+func (r *OutboxRepo) UnlockRelay(ctx context.Context) error {
+	return r.db.WithContext(ctx).Exec("DO RELEASE_LOCK(?)", outboxRelayLock).Error
+}
+
+// LockPending returns the oldest unpublished events. It must run inside a
+// transaction to hold the locks; rows are not skipped, so a relay that gets
+// past the relay lock early waits for the previous batch instead of
+// publishing after it.
+func (r *OutboxRepo) LockPending(ctx context.Context, limit int) ([]*entity.OutboxEvent, error) {
+	var events []*entity.OutboxEvent
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("published_at IS NULL").
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *OutboxRepo) MarkPublished(ctx context.Context, ids []string, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&entity.OutboxEvent{}).Where("id IN ?", ids).Update("published_at", at).Error
+}
+
+// DeletePublishedBefore deletes up to limit events published before the given
+// time and returns how many it deleted.
+func (r *OutboxRepo) DeletePublishedBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("published_at < ?", before).
+		Limit(limit).
+		Delete(&entity.OutboxEvent{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	v1 "bank-ledger/api/bankLedger/v1"
+	"bank-ledger/internal/entity"
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+type ScheduleRepository interface {
+	Create(ctx context.Context, req *entity.Schedule) error
+	Update(ctx context.Context, req *entity.Schedule) error
+	AdvanceOccurrence(ctx context.Context, req *entity.Schedule, occurrence time.Time) (bool, error)
+	FindByID(ctx context.Context, req *v1.BaseRequest) (*entity.Schedule, error)
+	ListByAccountID(ctx context.Context, accountID string) ([]*entity.Schedule, error)
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.Schedule, error)
+	WithTx(tx *gorm.DB) ScheduleRepository
+}
+
+type ScheduleRepo struct {
+	data *Data
+	db   *gorm.DB
+	log  *log.Helper
+}
+
+func NewScheduleRepo(data *Data, logger log.Logger) ScheduleRepository {
+	return &ScheduleRepo{
+		data: data,
+		db:   data.db,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *ScheduleRepo) WithTx(tx *gorm.DB) ScheduleRepository {
+	return &ScheduleRepo{
+		data: r.data,
+		db:   tx,
+		log:  r.log,
+	}
+}
+
+func (r *ScheduleRepo) Create(ctx context.Context, req *entity.Schedule) error {
+	if err := r.db.WithContext(ctx).Create(req).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *ScheduleRepo) Update(ctx context.Context, req *entity.Schedule) error {
+	req.UpdatedAt = time.Now()
+	if err := r.db.WithContext(ctx).Save(req).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// AdvanceOccurrence records the outcome of the occurrence due at occurrence. It
+// only writes the run columns and only while the schedule is still SCHEDULED
+// for that occurrence, so a concurrent pause, cancel or run is not overwritten;
+// it reports whether the row was updated.
+func (r *ScheduleRepo) AdvanceOccurrence(ctx context.Context, req *entity.Schedule, occurrence time.Time) (bool, error) {
+	req.UpdatedAt = time.Now()
+	result := r.db.WithContext(ctx).Model(&entity.Schedule{}).
+		Where("id = ? AND status = ? AND next_run_at = ?", req.ID, v1.ScheduleStatus_SCHEDULED.String(), occurrence).
+		Updates(map[string]interface{}{
+			"status":              req.Status,
+			"next_run_at":         req.NextRunAt,
+			"occurrences":         req.Occurrences,
+			"last_transaction_id": req.LastTransactionID,
+			"last_error":          req.LastError,
+			"updated_at":          req.UpdatedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ScheduleRepo) FindByID(ctx context.Context, req *v1.BaseRequest) (*entity.Schedule, error) {
+	var schedule entity.Schedule
+	if err := r.db.WithContext(ctx).First(&schedule, "id = ?", req.Id).Error; err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *ScheduleRepo) ListByAccountID(ctx context.Context, accountID string) ([]*entity.Schedule, error) {
+	var schedules []*entity.Schedule
+	if err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Order("created_at DESC").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *ScheduleRepo) FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.Schedule, error) {
+	var schedules []*entity.Schedule
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND next_run_at <= ?", v1.ScheduleStatus_SCHEDULED.String(), now).
+		Order("next_run_at ASC").
+		Limit(limit).
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
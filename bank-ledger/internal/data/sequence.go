@@ -0,0 +1,87 @@
+package data
+
+import (
+	"bank-ledger/internal/entity"
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SequenceRepository interface {
+	Next(ctx context.Context, accountID string) (int64, error)
+	Advance(ctx context.Context, accountID string, seq int64) (int64, error)
+	WithTx(tx *gorm.DB) SequenceRepository
+}
+
+type SequenceRepo struct {
+	data *Data
+	db   *gorm.DB
+	log  *log.Helper
+}
+
+func NewSequenceRepo(data *Data, logger log.Logger) SequenceRepository {
+	return &SequenceRepo{
+		data: data,
+		db:   data.db,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *SequenceRepo) WithTx(tx *gorm.DB) SequenceRepository {
+	return &SequenceRepo{
+		data: r.data,
+		db:   tx,
+		log:  r.log,
+	}
+}
+
+// Next issues the account's next command sequence number, starting at 1.
+func (r *SequenceRepo) Next(ctx context.Context, accountID string) (int64, error) {
+	var seq entity.AccountSequence
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "account_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"issued": gorm.Expr("issued + 1")}),
+		}).Create(&entity.AccountSequence{AccountID: accountID, Issued: 1}).Error
+		if err != nil {
+			return err
+		}
+		return tx.First(&seq, "account_id = ?", accountID).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return seq.Issued, nil
+}
+
+// Advance records seq as applied for the account and returns the highest
+// sequence applied before it. The row stays locked until the surrounding
+// transaction ends, so callers should pass a repository bound with WithTx.
+func (r *SequenceRepo) Advance(ctx context.Context, accountID string, seq int64) (int64, error) {
+	var current entity.AccountSequence
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("account_id = ?", accountID).
+		Limit(1).
+		Find(&current).Error
+	if err != nil {
+		return 0, err
+	}
+
+	if current.AccountID == "" {
+		current = entity.AccountSequence{AccountID: accountID, Issued: seq, Applied: seq}
+		return 0, r.db.WithContext(ctx).Create(&current).Error
+	}
+	if seq <= current.Applied {
+		return current.Applied, nil
+	}
+	err = r.db.WithContext(ctx).Model(&entity.AccountSequence{}).
+		Where("account_id = ?", accountID).
+		Update("applied", seq).Error
+	if err != nil {
+		return 0, err
+	}
+	return current.Applied, nil
+}
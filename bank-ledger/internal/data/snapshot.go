@@ -0,0 +1,85 @@
+package data
+
+import (
+	v1 "bank-ledger/api/bankLedger/v1"
+	"bank-ledger/internal/entity"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SnapshotRepository interface {
+	Save(ctx context.Context, snapshots ...*entity.BalanceSnapshot) error
+	FindLatest(ctx context.Context, accountID string, onOrBefore time.Time) (*entity.BalanceSnapshot, error)
+	FindDueAccounts(ctx context.Context, day time.Time, limit int) ([]*entity.Account, error)
+	WithTx(tx *gorm.DB) SnapshotRepository
+}
+
+type SnapshotRepo struct {
+	data *Data
+	db   *gorm.DB
+	log  *log.Helper
+}
+
+func NewSnapshotRepo(data *Data, logger log.Logger) SnapshotRepository {
+	return &SnapshotRepo{
+		data: data,
+		db:   data.db,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *SnapshotRepo) WithTx(tx *gorm.DB) SnapshotRepository {
+	return &SnapshotRepo{
+		data: r.data,
+		db:   tx,
+		log:  r.log,
+	}
+}
+
+// Save inserts snapshots, replacing any already taken for the same day.
+func (r *SnapshotRepo) Save(ctx context.Context, snapshots ...*entity.BalanceSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(snapshots).Error
+}
+
+// FindLatest returns the account's most recent snapshot for a day on or before
+// onOrBefore, or nil when there is none.
+func (r *SnapshotRepo) FindLatest(ctx context.Context, accountID string, onOrBefore time.Time) (*entity.BalanceSnapshot, error) {
+	var snapshot entity.BalanceSnapshot
+	err := r.db.WithContext(ctx).
+		Where("account_id = ? AND day <= ?", accountID, onOrBefore).
+		Order("day DESC").
+		First(&snapshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// FindDueAccounts returns open accounts that existed before the end of day and
+// have no snapshot for it.
+func (r *SnapshotRepo) FindDueAccounts(ctx context.Context, day time.Time, limit int) ([]*entity.Account, error) {
+	var accounts []*entity.Account
+	err := r.db.WithContext(ctx).
+		Where("status <> ? AND created_at < ?", v1.AccountStatus_CLOSED.String(), day.AddDate(0, 0, 1)).
+		Where("NOT EXISTS (?)", r.db.Model(&entity.BalanceSnapshot{}).
+			Select("1").
+			Where("balance_snapshots.account_id = accounts.id AND balance_snapshots.day = ?", day)).
+		Order("id ASC").
+		Limit(limit).
+		Find(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
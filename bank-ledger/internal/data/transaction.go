@@ -4,18 +4,59 @@ import (
 	v1 "bank-ledger/api/bankLedger/v1"
 	"bank-ledger/internal/entity"
 	"context"
+	"strings"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// TransactionFilter narrows an account's transaction listing. Zero values are ignored.
+type TransactionFilter struct {
+	Types       []string
+	Statuses    []string
+	MinAmount   float64
+	MaxAmount   float64
+	From        time.Time
+	To          time.Time
+	Description string
+}
+
+// TransactionCursor is the position of the last transaction of a page in
+// (created_at, id) descending order.
+type TransactionCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// TransactionSummary totals an account's SUCCESS transactions over a period:
+// deposits are credits, everything else debits.
+type TransactionSummary struct {
+	Credits     float64
+	Debits      float64
+	CreditCount int64
+	DebitCount  int64
+}
+
 type TransactionRepository interface {
 	Create(ctx context.Context, req *entity.Transaction) error
 	Update(ctx context.Context, req *entity.Transaction) error
 	FindByID(ctx context.Context, req *v1.BaseRequest) (*entity.Transaction, error)
+	FindByIDForUpdate(ctx context.Context, id string) (*entity.Transaction, error)
+	FindByIdempotencyKey(ctx context.Context, key string) (*entity.Transaction, error)
+	FindByIDs(ctx context.Context, ids []string) ([]*entity.Transaction, error)
 	ListAll(ctx context.Context) ([]*entity.Transaction, error)
-	FindByAccountIDWithPagination(ctx context.Context, accountID string, offset int, limit int) ([]*entity.Transaction, int64, error)
+	FindByAccountIDAfter(ctx context.Context, accountID string, filter TransactionFilter, after *TransactionCursor, limit int) ([]*entity.Transaction, error)
+	CountFeesSince(ctx context.Context, accountID string, rule string, since time.Time) (int64, error)
+	SumSuccessBefore(ctx context.Context, accountID string, before time.Time) (float64, error)
+	SumSuccess(ctx context.Context, accountID string) (float64, error)
+	SumSettledBefore(ctx context.Context, accountID string, before time.Time) (float64, error)
+	SummarizeSettled(ctx context.Context, accountID string, from time.Time, to time.Time) (TransactionSummary, error)
+	FindSuccessInRange(ctx context.Context, accountID string, from time.Time, to time.Time) ([]*entity.Transaction, error)
+	LockStale(ctx context.Context, status string, before time.Time, limit int) ([]*entity.Transaction, error)
+	CountStale(ctx context.Context, status string, before time.Time) (int64, error)
+	FindLoggedAfter(ctx context.Context, afterID string, before time.Time, limit int) ([]*entity.Transaction, error)
 	WithTx(tx *gorm.DB) TransactionRepository
 }
 
@@ -64,6 +105,38 @@ func (r *TransactionRepo) FindByID(ctx context.Context, req *v1.BaseRequest) (*e
 	return &txn, nil
 }
 
+// FindByIDForUpdate reads the transaction and locks its row until the
+// surrounding transaction ends. Call it on a repository bound with WithTx.
+func (r *TransactionRepo) FindByIDForUpdate(ctx context.Context, id string) (*entity.Transaction, error) {
+	var txn entity.Transaction
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&txn, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &txn, nil
+}
+
+func (r *TransactionRepo) FindByIdempotencyKey(ctx context.Context, key string) (*entity.Transaction, error) {
+	var txn entity.Transaction
+	if err := r.db.WithContext(ctx).First(&txn, "idempotency_key = ?", key).Error; err != nil {
+		return nil, err
+	}
+	return &txn, nil
+}
+
+func (r *TransactionRepo) FindByIDs(ctx context.Context, ids []string) ([]*entity.Transaction, error) {
+	var transactions []*entity.Transaction
+	if len(ids) == 0 {
+		return transactions, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
 func (r *TransactionRepo) ListAll(ctx context.Context) ([]*entity.Transaction, error) {
 	var transactions []*entity.Transaction
 	if err := r.db.WithContext(ctx).Find(&transactions).Error; err != nil {
@@ -72,19 +145,175 @@ func (r *TransactionRepo) ListAll(ctx context.Context) ([]*entity.Transaction, e
 	return transactions, nil
 }
 
-func (r *TransactionRepo) FindByAccountIDWithPagination(ctx context.Context, accountID string, offset int, limit int) ([]*entity.Transaction, int64, error) {
+func (r *TransactionRepo) FindByAccountIDAfter(ctx context.Context, accountID string, filter TransactionFilter, after *TransactionCursor, limit int) ([]*entity.Transaction, error) {
 	var transactions []*entity.Transaction
-	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.Transaction{}).Where("account_id = ?", accountID)
+	query := r.db.WithContext(ctx).Where("account_id = ?", accountID)
 
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if len(filter.Types) > 0 {
+		query = query.Where("type IN ?", filter.Types)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.MinAmount > 0 {
+		query = query.Where("amount >= ?", filter.MinAmount)
+	}
+	if filter.MaxAmount > 0 {
+		query = query.Where("amount <= ?", filter.MaxAmount)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+	if filter.Description != "" {
+		query = query.Where("LOWER(description) LIKE ?", "%"+escapeLike(strings.ToLower(filter.Description))+"%")
+	}
+	if after != nil {
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", after.CreatedAt, after.CreatedAt, after.ID)
 	}
 
-	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&transactions).Error; err != nil {
-		return nil, 0, err
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&transactions).Error; err != nil {
+		return nil, err
 	}
 
-	return transactions, total, nil
+	return transactions, nil
+}
+
+func (r *TransactionRepo) CountFeesSince(ctx context.Context, accountID string, rule string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.Transaction{}).
+		Where("account_id = ? AND fee_rule = ? AND created_at >= ?", accountID, rule, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// SumSuccessBefore returns the net effect of all SUCCESS transactions booked on
+// the account before the given time: deposits count as credits, everything else
+// as debits.
+func (r *TransactionRepo) SumSuccessBefore(ctx context.Context, accountID string, before time.Time) (float64, error) {
+	var sum float64
+	err := r.db.WithContext(ctx).Model(&entity.Transaction{}).
+		Select("COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE -amount END), 0)", v1.TransactionType_DEPOSIT.String()).
+		Where("account_id = ? AND status = ? AND created_at < ?", accountID, v1.TransactionStatus_SUCCESS.String(), before).
+		Scan(&sum).Error
+	if err != nil {
+		return 0, err
+	}
+	return sum, nil
+}
+
+// SumSuccess returns the net effect of every SUCCESS transaction booked on the
+// account, which should equal its balance.
+func (r *TransactionRepo) SumSuccess(ctx context.Context, accountID string) (float64, error) {
+	var sum float64
+	err := r.db.WithContext(ctx).Model(&entity.Transaction{}).
+		Select("COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE -amount END), 0)", v1.TransactionType_DEPOSIT.String()).
+		Where("account_id = ? AND status = ?", accountID, v1.TransactionStatus_SUCCESS.String()).
+		Scan(&sum).Error
+	if err != nil {
+		return 0, err
+	}
+	return sum, nil
+}
+
+// settledAt is when a SUCCESS transaction changed the balance. Rows written
+// before processed_at existed fall back to their creation time.
+const settledAt = "COALESCE(processed_at, created_at)"
+
+// SumSettledBefore returns the net effect of the SUCCESS transactions that
+// settled on the account before the given time, which is its balance then.
+func (r *TransactionRepo) SumSettledBefore(ctx context.Context, accountID string, before time.Time) (float64, error) {
+	var sum float64
+	err := r.db.WithContext(ctx).Model(&entity.Transaction{}).
+		Select("COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE -amount END), 0)", v1.TransactionType_DEPOSIT.String()).
+		Where("account_id = ? AND status = ? AND "+settledAt+" < ?", accountID, v1.TransactionStatus_SUCCESS.String(), before).
+		Scan(&sum).Error
+	if err != nil {
+		return 0, err
+	}
+	return sum, nil
+}
+
+// SummarizeSettled totals the SUCCESS transactions that settled on the account
+// in [from, to).
+func (r *TransactionRepo) SummarizeSettled(ctx context.Context, accountID string, from time.Time, to time.Time) (TransactionSummary, error) {
+	var summary TransactionSummary
+	deposit := v1.TransactionType_DEPOSIT.String()
+	err := r.db.WithContext(ctx).Model(&entity.Transaction{}).
+		Select(`COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE 0 END), 0) AS credits,
+			COALESCE(SUM(CASE WHEN type <> ? THEN amount ELSE 0 END), 0) AS debits,
+			COALESCE(SUM(CASE WHEN type = ? THEN 1 ELSE 0 END), 0) AS credit_count,
+			COALESCE(SUM(CASE WHEN type <> ? THEN 1 ELSE 0 END), 0) AS debit_count`, deposit, deposit, deposit, deposit).
+		Where("account_id = ? AND status = ? AND "+settledAt+" >= ? AND "+settledAt+" < ?", accountID, v1.TransactionStatus_SUCCESS.String(), from, to).
+		Scan(&summary).Error
+	if err != nil {
+		return TransactionSummary{}, err
+	}
+	return summary, nil
+}
+
+func (r *TransactionRepo) FindSuccessInRange(ctx context.Context, accountID string, from time.Time, to time.Time) ([]*entity.Transaction, error) {
+	var transactions []*entity.Transaction
+	err := r.db.WithContext(ctx).
+		Where("account_id = ? AND status = ? AND created_at >= ? AND created_at < ?", accountID, v1.TransactionStatus_SUCCESS.String(), from, to).
+		Order("created_at ASC, id ASC").
+		Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// LockStale returns the transactions in status that have not changed since
+// before, oldest first, skipping rows locked by another sweeper.
+func (r *TransactionRepo) LockStale(ctx context.Context, status string, before time.Time, limit int) ([]*entity.Transaction, error) {
+	var transactions []*entity.Transaction
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND updated_at < ?", status, before).
+		Order("updated_at ASC").
+		Limit(limit).
+		Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func (r *TransactionRepo) CountStale(ctx context.Context, status string, before time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.Transaction{}).
+		Where("status = ? AND updated_at < ?", status, before).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindLoggedAfter pages, in id order, through the transactions that have a
+// MongoDB log: everything but fees and transfer credit legs. Transactions
+// changed at or after before are left out.
+func (r *TransactionRepo) FindLoggedAfter(ctx context.Context, afterID string, before time.Time, limit int) ([]*entity.Transaction, error) {
+	var transactions []*entity.Transaction
+	err := r.db.WithContext(ctx).
+		Where("id > ? AND updated_at < ?", afterID, before).
+		Where("type <> ? AND (parent_transaction_id = '' OR parent_transaction_id IS NULL)", v1.TransactionType_FEE.String()).
+		Order("id ASC").
+		Limit(limit).
+		Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func escapeLike(val string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(val)
 }
@@ -18,6 +18,8 @@ type TransactionLogsRepository interface {
 	CreateTransaction(ctx context.Context, txn *entity.TransactionLog) error
 	AppendTransactionLog(ctx context.Context, txnID string, retryAttempt int, entry entity.LogEntry) error
 	GetTransaction(ctx context.Context, txnID string) (*entity.TransactionLog, error)
+	FindTransactions(ctx context.Context, txnIDs []string) ([]*entity.TransactionLog, error)
+	ListTransactionIDs(ctx context.Context, afterID string, limit int) ([]string, error)
 }
 
 type TransactionLogsRepo struct {
@@ -164,3 +166,48 @@ func (r *TransactionLogsRepo) GetTransaction(ctx context.Context, txnID string)
 
 	return &txn, nil
 }
+
+// FindTransactions returns the log documents of the given transactions without
+// their log entries.
+func (r *TransactionLogsRepo) FindTransactions(ctx context.Context, txnIDs []string) ([]*entity.TransactionLog, error) {
+	var txns []*entity.TransactionLog
+	if len(txnIDs) == 0 {
+		return txns, nil
+	}
+
+	collection := r.mongo.Collection("transactions")
+	opts := options.Find().SetProjection(bson.M{"transactionLogs": 0, "transactionlogs": 0})
+	cursor, err := collection.Find(ctx, bson.M{"transactionid": bson.M{"$in": txnIDs}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transactions: %w", err)
+	}
+	if err := cursor.All(ctx, &txns); err != nil {
+		return nil, fmt.Errorf("failed to decode transactions: %w", err)
+	}
+	return txns, nil
+}
+
+// ListTransactionIDs pages through the logged transaction IDs in order.
+func (r *TransactionLogsRepo) ListTransactionIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	collection := r.mongo.Collection("transactions")
+	opts := options.Find().
+		SetProjection(bson.M{"transactionid": 1}).
+		SetSort(bson.M{"transactionid": 1}).
+		SetLimit(int64(limit))
+	cursor, err := collection.Find(ctx, bson.M{"transactionid": bson.M{"$gt": afterID}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+
+	var docs []struct {
+		TransactionID string `bson:"transactionid"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("failed to decode transactions: %w", err)
+	}
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.TransactionID)
+	}
+	return ids, nil
+}
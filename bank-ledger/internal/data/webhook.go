@@ -0,0 +1,178 @@
+package data
+
+import (
+	v1 "bank-ledger/api/bankLedger/v1"
+	"bank-ledger/internal/entity"
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, req *entity.WebhookSubscription) error
+	UpdateSubscription(ctx context.Context, req *entity.WebhookSubscription) error
+	FindSubscriptionByID(ctx context.Context, req *v1.BaseRequest) (*entity.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, clientID string) ([]*entity.WebhookSubscription, error)
+	ListActiveSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	CreateDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error
+	UpdateDelivery(ctx context.Context, req *entity.WebhookDelivery) error
+	FindDeliveryByID(ctx context.Context, req *v1.BaseRequest) (*entity.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, subscriptionID string, status string, limit int) ([]*entity.WebhookDelivery, error)
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error)
+	ClaimDelivery(ctx context.Context, req *entity.WebhookDelivery, until time.Time) (bool, error)
+	CreateAttempt(ctx context.Context, req *entity.WebhookAttempt) error
+	FindAttempts(ctx context.Context, deliveryIDs []string) ([]*entity.WebhookAttempt, error)
+	WithTx(tx *gorm.DB) WebhookRepository
+}
+
+type WebhookRepo struct {
+	data *Data
+	db   *gorm.DB
+	log  *log.Helper
+}
+
+func NewWebhookRepo(data *Data, logger log.Logger) WebhookRepository {
+	return &WebhookRepo{
+		data: data,
+		db:   data.db,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *WebhookRepo) WithTx(tx *gorm.DB) WebhookRepository {
+	return &WebhookRepo{
+		data: r.data,
+		db:   tx,
+		log:  r.log,
+	}
+}
+
+func (r *WebhookRepo) CreateSubscription(ctx context.Context, req *entity.WebhookSubscription) error {
+	if err := r.db.WithContext(ctx).Create(req).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *WebhookRepo) UpdateSubscription(ctx context.Context, req *entity.WebhookSubscription) error {
+	req.UpdatedAt = time.Now()
+	if err := r.db.WithContext(ctx).Save(req).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *WebhookRepo) FindSubscriptionByID(ctx context.Context, req *v1.BaseRequest) (*entity.WebhookSubscription, error) {
+	var subscription entity.WebhookSubscription
+	if err := r.db.WithContext(ctx).First(&subscription, "id = ?", req.Id).Error; err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *WebhookRepo) ListSubscriptions(ctx context.Context, clientID string) ([]*entity.WebhookSubscription, error) {
+	var subscriptions []*entity.WebhookSubscription
+	query := r.db.WithContext(ctx)
+	if clientID != "" {
+		query = query.Where("client_id = ?", clientID)
+	}
+	if err := query.Order("created_at DESC").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookRepo) ListActiveSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	var subscriptions []*entity.WebhookSubscription
+	if err := r.db.WithContext(ctx).Where("active = ?", true).Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookRepo) CreateDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(deliveries).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *WebhookRepo) UpdateDelivery(ctx context.Context, req *entity.WebhookDelivery) error {
+	req.UpdatedAt = time.Now()
+	if err := r.db.WithContext(ctx).Save(req).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *WebhookRepo) FindDeliveryByID(ctx context.Context, req *v1.BaseRequest) (*entity.WebhookDelivery, error) {
+	var delivery entity.WebhookDelivery
+	if err := r.db.WithContext(ctx).First(&delivery, "id = ?", req.Id).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookRepo) ListDeliveries(ctx context.Context, subscriptionID string, status string, limit int) ([]*entity.WebhookDelivery, error) {
+	var deliveries []*entity.WebhookDelivery
+	query := r.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepo) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	var deliveries []*entity.WebhookDelivery
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", v1.WebhookDeliveryStatus_DELIVERY_PENDING.String(), now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimDelivery pushes next_attempt_at out to until, provided no other worker
+// has claimed the delivery since it was read. It reports whether the claim won.
+func (r *WebhookRepo) ClaimDelivery(ctx context.Context, req *entity.WebhookDelivery, until time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", req.ID, req.Status, req.NextAttemptAt).
+		Update("next_attempt_at", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	req.NextAttemptAt = until
+	return true, nil
+}
+
+func (r *WebhookRepo) CreateAttempt(ctx context.Context, req *entity.WebhookAttempt) error {
+	if err := r.db.WithContext(ctx).Create(req).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *WebhookRepo) FindAttempts(ctx context.Context, deliveryIDs []string) ([]*entity.WebhookAttempt, error) {
+	var attempts []*entity.WebhookAttempt
+	if len(deliveryIDs) == 0 {
+		return attempts, nil
+	}
+	if err := r.db.WithContext(ctx).Where("delivery_id IN ?", deliveryIDs).Order("id ASC").Find(&attempts).Error; err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
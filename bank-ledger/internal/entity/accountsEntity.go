@@ -7,13 +7,13 @@ import (
 )
 
 type Account struct {
-	ID            string  `gorm:"primaryKey;size:21"`
-	AccountNumber string  `gorm:"size:100;uniqueIndex;not null"`
-	Name          string  `gorm:"size:255;not null"`
-	Balance       float64 `gorm:"type:decimal(20,2);not null"`
-	Currency      string  `gorm:"size:3;not null"`
-	Status        string  `gorm:"size:20;default:ACTIVE"`
-	CreatedAt     time.Time
+	ID            string    `gorm:"primaryKey;size:21"`
+	AccountNumber string    `gorm:"size:100;uniqueIndex;not null"`
+	Name          string    `gorm:"size:255;not null;index"`
+	Balance       float64   `gorm:"type:decimal(20,2);not null"`
+	Currency      string    `gorm:"size:3;not null"`
+	Status        string    `gorm:"size:20;default:ACTIVE"`
+	CreatedAt     time.Time `gorm:"index"`
 	UpdatedAt     time.Time
 }
 
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Batch struct {
+	ID         string `gorm:"primaryKey;size:21"`
+	Name       string `gorm:"size:255"`
+	Format     string `gorm:"size:20;not null"`
+	Status     string `gorm:"size:20;index"`
+	TotalLines int    `gorm:"default:0"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type BatchLine struct {
+	ID              uint    `gorm:"primaryKey;autoIncrement"`
+	BatchID         string  `gorm:"size:21;not null;uniqueIndex:idx_batch_line,priority:1"`
+	LineNumber      int     `gorm:"not null;uniqueIndex:idx_batch_line,priority:2"`
+	AccountRef      string  `gorm:"size:100;not null"`
+	CounterpartyRef string  `gorm:"size:100"`
+	Amount          float64 `gorm:"type:decimal(20,2);not null"`
+	Currency        string  `gorm:"size:3"`
+	Type            string  `gorm:"size:20;not null"`
+	Description     string  `gorm:"type:text"`
+	Status          string  `gorm:"size:20;index"`
+	Error           string  `gorm:"type:text"`
+	TransactionID   string  `gorm:"size:21"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (b *Batch) BeforeCreate(tx *gorm.DB) (err error) {
+	now := time.Now()
+	b.CreatedAt = now
+	b.UpdatedAt = now
+	return
+}
+
+func (b *Batch) BeforeUpdate(tx *gorm.DB) (err error) {
+	b.UpdatedAt = time.Now()
+	return
+}
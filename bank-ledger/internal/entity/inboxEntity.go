@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+)
+
+// ProcessedMessage marks a transaction command the consumer has applied. It is
+// written in the same database transaction as the balance change, so a
+// redelivered command can be recognised and skipped.
+type ProcessedMessage struct {
+	TransactionID string    `gorm:"primaryKey;size:21"`
+	Topic         string    `gorm:"size:128;not null"`
+	Partition     int32     `gorm:"not null"`
+	Offset        int64     `gorm:"not null"`
+	ProcessedAt   time.Time `gorm:"index"`
+}
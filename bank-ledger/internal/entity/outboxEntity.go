@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+)
+
+// OutboxEvent is an event written in the same database transaction as the
+// change it describes and later relayed to Kafka.
+type OutboxEvent struct {
+	ID          string     `gorm:"primaryKey;size:21"`
+	Topic       string     `gorm:"size:128;not null"`
+	Key         string     `gorm:"size:64;not null"`
+	EventType   string     `gorm:"size:64;not null"`
+	Payload     []byte     `gorm:"type:blob;not null"`
+	Attempts    int        `gorm:"default:0"`
+	LastError   string     `gorm:"type:text"`
+	PublishedAt *time.Time `gorm:"index"`
+	CreatedAt   time.Time  `gorm:"index"`
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Schedule struct {
+	ID                    string  `gorm:"primaryKey;size:21"`
+	AccountID             string  `gorm:"size:21;not null;index"`
+	CounterpartyAccountID string  `gorm:"size:21"`
+	Amount                float64 `gorm:"type:decimal(20,2);not null"`
+	Type                  string  `gorm:"size:20;not null"`
+	Description           string  `gorm:"type:text"`
+	Frequency             string  `gorm:"size:20;not null"`
+	Status                string  `gorm:"size:20;index"`
+	StartAt               time.Time
+	EndAt                 *time.Time
+	NextRunAt             *time.Time `gorm:"index"`
+	Occurrences           int        `gorm:"default:0"`
+	LastTransactionID     string     `gorm:"size:21"`
+	LastError             string     `gorm:"type:text"`
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+func (s *Schedule) BeforeCreate(tx *gorm.DB) (err error) {
+	now := time.Now()
+	s.CreatedAt = now
+	s.UpdatedAt = now
+	return
+}
+
+func (s *Schedule) BeforeUpdate(tx *gorm.DB) (err error) {
+	s.UpdatedAt = time.Now()
+	return
+}
@@ -0,0 +1,10 @@
+package entity
+
+// AccountSequence tracks the per-account command sequence. Issued is the last
+// number handed out by the API and Applied the highest one the consumer has
+// committed. It lives outside Account so account updates cannot reset it.
+type AccountSequence struct {
+	AccountID string `gorm:"primaryKey;size:21"`
+	Issued    int64  `gorm:"not null;default:0"`
+	Applied   int64  `gorm:"not null;default:0"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+)
+
+// BalanceSnapshot is an account's closing position at the end of Day: the
+// balance and the day's totals over SUCCESS transactions settled before the
+// following midnight.
+type BalanceSnapshot struct {
+	AccountID      string    `gorm:"primaryKey;size:21"`
+	Day            time.Time `gorm:"primaryKey;type:date"`
+	ClosingBalance float64   `gorm:"type:decimal(20,2);not null"`
+	Credits        float64   `gorm:"type:decimal(20,2);not null"`
+	Debits         float64   `gorm:"type:decimal(20,2);not null"`
+	CreditCount    int64     `gorm:"not null;default:0"`
+	DebitCount     int64     `gorm:"not null;default:0"`
+	CreatedAt      time.Time
+}
@@ -11,9 +11,12 @@ type TryLog struct {
 }
 
 type LogEntry struct {
-	Timestamp time.Time `json:"timestamp"`
-	Message   string    `json:"message"`
-	Status    string    `json:"status"`
+	Timestamp     time.Time `json:"timestamp"`
+	Message       string    `json:"message"`
+	Status        string    `json:"status"`
+	RequestID     string    `json:"request_id,omitempty"`
+	CorrelationID string    `json:"correlation_id,omitempty"`
+	TraceID       string    `json:"trace_id,omitempty"`
 }
 
 type TransactionLog struct {
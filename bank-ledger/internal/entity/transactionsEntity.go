@@ -7,17 +7,26 @@ import (
 )
 
 type Transaction struct {
-	ID                 string  `gorm:"primaryKey;size:21"`
-	AccountID          string  `gorm:"size:21;not null"`
-	Amount             float64 `gorm:"type:decimal(20,2);not null"`
-	Currency           string  `gorm:"size:3;not null"`
-	Type               string  `gorm:"size:20;not null"`
-	Status             string  `gorm:"size:20;"`
-	Description        string  `gorm:"type:text"`
-	ProcessDescription string  `gorm:"type:text"`
-	RetryCount         int     `gorm:"default:0"`
-	CreatedAt          time.Time
-	UpdatedAt          time.Time
+	ID                    string    `gorm:"primaryKey;size:21;index:idx_transactions_account_created,priority:3"`
+	AccountID             string    `gorm:"size:21;not null;index:idx_transactions_account_created,priority:1"`
+	Amount                float64   `gorm:"type:decimal(20,2);not null"`
+	Currency              string    `gorm:"size:3;not null"`
+	Type                  string    `gorm:"size:20;not null"`
+	Status                string    `gorm:"size:20;"`
+	Description           string    `gorm:"type:text"`
+	ProcessDescription    string    `gorm:"type:text"`
+	RetryCount            int       `gorm:"default:0"`
+	SweepAttempts         int       `gorm:"not null;default:0"`
+	ParentTransactionID   string    `gorm:"size:21;index"`
+	FeeRule               string    `gorm:"size:64"`
+	CounterpartyAccountID string    `gorm:"size:21"`
+	IdempotencyKey        *string   `gorm:"size:128;uniqueIndex"`
+	Sequence              int64     `gorm:"default:0"`
+	CreatedAt             time.Time `gorm:"index:idx_transactions_account_created,priority:2"`
+	UpdatedAt             time.Time
+	// ProcessedAt is when the transaction reached SUCCESS and changed the
+	// balance, which can be well after CreatedAt.
+	ProcessedAt *time.Time
 }
 
 func (t *Transaction) BeforeUpdate(tx *gorm.DB) (err error) {
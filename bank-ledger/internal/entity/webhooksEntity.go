@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WebhookSubscription struct {
+	ID         string `gorm:"primaryKey;size:21"`
+	ClientID   string `gorm:"size:64;not null;index"`
+	URL        string `gorm:"size:2048;not null"`
+	EventTypes string `gorm:"size:512;not null"`
+	AccountIDs string `gorm:"size:1100;not null;default:''"`
+	Secret     string `gorm:"size:128;not null"`
+	Active     bool   `gorm:"default:true;index"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (w *WebhookSubscription) BeforeCreate(tx *gorm.DB) (err error) {
+	now := time.Now()
+	w.CreatedAt = now
+	w.UpdatedAt = now
+	return
+}
+
+func (w *WebhookSubscription) BeforeUpdate(tx *gorm.DB) (err error) {
+	w.UpdatedAt = time.Now()
+	return
+}
+
+type WebhookDelivery struct {
+	ID             string    `gorm:"primaryKey;size:21"`
+	SubscriptionID string    `gorm:"size:21;not null;index"`
+	EventID        string    `gorm:"size:21;not null;index"`
+	EventType      string    `gorm:"size:64;not null"`
+	Payload        string    `gorm:"type:text;not null"`
+	Status         string    `gorm:"size:20;not null;index:idx_webhook_deliveries_due,priority:1"`
+	Attempts       int       `gorm:"default:0"`
+	NextAttemptAt  time.Time `gorm:"index:idx_webhook_deliveries_due,priority:2"`
+	LastStatusCode int
+	LastError      string `gorm:"type:text"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) (err error) {
+	now := time.Now()
+	d.CreatedAt = now
+	d.UpdatedAt = now
+	return
+}
+
+func (d *WebhookDelivery) BeforeUpdate(tx *gorm.DB) (err error) {
+	d.UpdatedAt = time.Now()
+	return
+}
+
+type WebhookAttempt struct {
+	ID         uint   `gorm:"primaryKey"`
+	DeliveryID string `gorm:"size:21;not null;index"`
+	Attempt    int    `gorm:"not null"`
+	StatusCode int
+	Error      string `gorm:"type:text"`
+	DurationMs int64
+	CreatedAt  time.Time
+}
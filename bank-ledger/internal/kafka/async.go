@@ -0,0 +1,101 @@
+package kafka
+
+import (
+	"bank-ledger/internal/conf"
+	"sync"
+
+	"github.com/IBM/sarama"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type asyncProducer struct {
+	producer sarama.AsyncProducer
+	log      *log.Helper
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func newAsyncProducer(c *conf.Data_Kafka, config *sarama.Config, l *log.Helper) (Producer, error) {
+	if a := c.Async; a != nil {
+		config.Producer.Flush.Messages = int(a.FlushMessages)
+		config.Producer.Flush.Bytes = int(a.FlushBytes)
+		if a.FlushFrequency != nil {
+			config.Producer.Flush.Frequency = a.FlushFrequency.AsDuration()
+		}
+		if a.ChannelBufferSize > 0 {
+			config.ChannelBufferSize = int(a.ChannelBufferSize)
+		}
+	}
+	config.Producer.Return.Errors = true
+	// Keep per-partition order when batches are retried.
+	config.Net.MaxOpenRequests = 1
+
+	producer, err := sarama.NewAsyncProducer(c.Brokers, config)
+	if err != nil {
+		l.Errorf("failed to create async kafka producer: %v", err)
+		return nil, err
+	}
+
+	p := &asyncProducer{producer: producer, log: l}
+	p.wg.Add(2)
+	go func() {
+		defer p.wg.Done()
+		for msg := range producer.Successes() {
+			p.deliver(msg, nil)
+		}
+	}()
+	go func() {
+		defer p.wg.Done()
+		for perr := range producer.Errors() {
+			p.log.Errorf("failed to send message: %v", perr.Err)
+			p.deliver(perr.Msg, perr.Err)
+		}
+	}()
+
+	l.Infof("async kafka producer created successfully, brokers: %v", c.Brokers)
+	return p, nil
+}
+
+func (p *asyncProducer) deliver(msg *sarama.ProducerMessage, err error) {
+	if done, ok := msg.Metadata.(DeliveryFunc); ok && done != nil {
+		done(err)
+	}
+}
+
+func (p *asyncProducer) SendMessage(topic string, key, value []byte, headers ...Header) error {
+	result := make(chan error, 1)
+	if err := p.SendMessageAsync(topic, key, value, func(err error) { result <- err }, headers...); err != nil {
+		return err
+	}
+	return <-result
+}
+
+func (p *asyncProducer) SendMessageAsync(topic string, key, value []byte, done DeliveryFunc, headers ...Header) error {
+	msg := newMessage(topic, key, value, headers)
+	msg.Metadata = done
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrProducerClosed
+	}
+	p.producer.Input() <- msg
+	return nil
+}
+
+// Close flushes buffered messages and waits for their delivery callbacks.
+func (p *asyncProducer) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+
+	// AsyncClose rather than Close: Close drains the result channels itself
+	// and would drop callbacks.
+	p.producer.AsyncClose()
+	p.wg.Wait()
+
+	p.log.Info("producer closed successfully")
+	return nil
+}
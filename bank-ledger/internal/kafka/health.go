@@ -0,0 +1,23 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/IBM/sarama"
+)
+
+// Ping refreshes the metadata of topics through client, failing when no broker
+// answers before ctx is done.
+func Ping(ctx context.Context, client sarama.Client, topics ...string) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- client.RefreshMetadata(topics...)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -2,15 +2,116 @@ package kafka
 
 import (
 	"bank-ledger/internal/conf"
+	"bank-ledger/internal/propagation"
+	"bank-ledger/internal/telemetry"
+	"context"
+	"errors"
 	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel"
+	otelpropagation "go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	HeaderContentType   = "content-type"
+	HeaderSchema        = "schema"
+	HeaderSchemaVersion = "schema-version"
+	HeaderRequestID     = "request-id"
+	HeaderCorrelationID = "correlation-id"
+	HeaderTraceParent   = "traceparent"
+	HeaderTraceState    = "tracestate"
+)
+
+// Header is a kafka record header.
+type Header struct {
+	Key   string
+	Value string
+}
+
+// ContextHeaders returns the propagation headers for the request in ctx. An
+// active span's context supersedes the caller's trace headers, so consumers
+// continue the trace from that span.
+func ContextHeaders(ctx context.Context) []Header {
+	v, _ := propagation.FromContext(ctx)
+
+	carrier := otelpropagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if traceParent := carrier.Get(HeaderTraceParent); traceParent != "" {
+		v.TraceParent = traceParent
+		v.TraceState = carrier.Get(HeaderTraceState)
+	}
+
+	var headers []Header
+	for _, h := range []Header{
+		{Key: HeaderRequestID, Value: v.RequestID},
+		{Key: HeaderCorrelationID, Value: v.CorrelationID},
+		{Key: HeaderTraceParent, Value: v.TraceParent},
+		{Key: HeaderTraceState, Value: v.TraceState},
+	} {
+		if h.Value != "" {
+			headers = append(headers, h)
+		}
+	}
+	return headers
+}
+
+// ContextFromHeaders returns a copy of ctx carrying the propagation headers of
+// a record, including the remote span context they describe.
+func ContextFromHeaders(ctx context.Context, headers map[string]string) context.Context {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, otelpropagation.MapCarrier(headers))
+	return propagation.NewContext(ctx, propagation.Values{
+		RequestID:     headers[HeaderRequestID],
+		CorrelationID: headers[HeaderCorrelationID],
+		TraceParent:   headers[HeaderTraceParent],
+		TraceState:    headers[HeaderTraceState],
+	})
+}
+
+// StartProducerSpan starts a span for publishing a message to topic. Take the
+// message headers from the returned context so they carry the span.
+func StartProducerSpan(ctx context.Context, topic string) (context.Context, trace.Span) {
+	return telemetry.Tracer().Start(ctx, topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationPublish,
+			semconv.MessagingDestinationName(topic),
+		),
+	)
+}
+
+// StartConsumerSpan starts a span for processing a consumed message, as a child
+// of the producer's span when ctx carries one (see ContextFromHeaders).
+func StartConsumerSpan(ctx context.Context, topic string, partition int32, offset int64) (context.Context, trace.Span) {
+	return telemetry.Tracer().Start(ctx, topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(topic),
+			semconv.MessagingKafkaDestinationPartition(int(partition)),
+			semconv.MessagingKafkaMessageOffset(int(offset)),
+		),
+	)
+}
+
+// ErrProducerClosed is returned for messages sent after Close.
+var ErrProducerClosed = errors.New("kafka: producer is closed")
+
+// DeliveryFunc receives the broker's result for a message sent with SendMessageAsync.
+type DeliveryFunc func(err error)
+
 // Producer is a kafka producer interface
 type Producer interface {
-	SendMessage(topic string, key, value []byte) error
+	// SendMessage blocks until the broker acknowledges the message.
+	SendMessage(topic string, key, value []byte, headers ...Header) error
+	// SendMessageAsync hands the message to the producer. An error means it was
+	// not accepted; otherwise done, if set, is called once with the delivery
+	// result. Synchronous producers deliver before returning.
+	SendMessageAsync(topic string, key, value []byte, done DeliveryFunc, headers ...Header) error
 	Close() error
 }
 
@@ -22,16 +123,14 @@ type kafkaProducer struct {
 func NewProducer(c *conf.Data, logger log.Logger) (Producer, error) {
 	l := log.NewHelper(log.With(logger, "module", "kafka/producer"))
 
-	config := sarama.NewConfig()
-	config.Producer.RequiredAcks = sarama.RequiredAcks(c.Kafka.RequiredAcks)
-	config.Producer.Retry.Max = int(c.Kafka.Retries)
-	config.Producer.Return.Successes = true
+	config, err := producerConfig(c.Kafka)
+	if err != nil {
+		l.Errorf("invalid kafka producer config: %v", err)
+		return nil, err
+	}
 
-	if c.Kafka.Timeout != nil {
-		timeout := c.Kafka.Timeout.AsDuration()
-		config.Producer.Timeout = timeout
-	} else {
-		config.Producer.Timeout = 3 * time.Second
+	if c.Kafka.Async.GetEnabled() {
+		return newAsyncProducer(c.Kafka, config, l)
 	}
 
 	producer, err := sarama.NewSyncProducer(c.Kafka.Brokers, config)
@@ -48,17 +147,45 @@ func NewProducer(c *conf.Data, logger log.Logger) (Producer, error) {
 	}, nil
 }
 
-func (p *kafkaProducer) SendMessage(topic string, key, value []byte) error {
+func producerConfig(c *conf.Data_Kafka) (*sarama.Config, error) {
+	config := sarama.NewConfig()
+	config.Producer.RequiredAcks = sarama.RequiredAcks(c.RequiredAcks)
+	config.Producer.Retry.Max = int(c.Retries)
+	config.Producer.Return.Successes = true
+
+	if c.Timeout != nil {
+		timeout := c.Timeout.AsDuration()
+		config.Producer.Timeout = timeout
+	} else {
+		config.Producer.Timeout = 3 * time.Second
+	}
+
+	if c.Compression != "" {
+		if err := config.Producer.Compression.UnmarshalText([]byte(c.Compression)); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+func newMessage(topic string, key, value []byte, headers []Header) *sarama.ProducerMessage {
 	msg := &sarama.ProducerMessage{
 		Topic: topic,
 		Value: sarama.ByteEncoder(value),
 	}
 
+	for _, h := range headers {
+		msg.Headers = append(msg.Headers, sarama.RecordHeader{Key: []byte(h.Key), Value: []byte(h.Value)})
+	}
+
 	if key != nil {
 		msg.Key = sarama.ByteEncoder(key)
 	}
+	return msg
+}
 
-	partition, offset, err := p.producer.SendMessage(msg)
+func (p *kafkaProducer) SendMessage(topic string, key, value []byte, headers ...Header) error {
+	partition, offset, err := p.producer.SendMessage(newMessage(topic, key, value, headers))
 	if err != nil {
 		p.log.Errorf("failed to send message: %v", err)
 		return err
@@ -68,6 +195,16 @@ func (p *kafkaProducer) SendMessage(topic string, key, value []byte) error {
 	return nil
 }
 
+func (p *kafkaProducer) SendMessageAsync(topic string, key, value []byte, done DeliveryFunc, headers ...Header) error {
+	if err := p.SendMessage(topic, key, value, headers...); err != nil {
+		return err
+	}
+	if done != nil {
+		done(nil)
+	}
+	return nil
+}
+
 func (p *kafkaProducer) Close() error {
 	if err := p.producer.Close(); err != nil {
 		p.log.Errorf("failed to close producer: %v", err)
@@ -0,0 +1,108 @@
+package kafka
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"bank-ledger/internal/conf"
+
+	"github.com/IBM/sarama"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// benchTopic receives the benchmark messages.
+const benchTopic = "bank-ledger.bench"
+
+// benchBrokers returns the brokers to benchmark against: BENCH_KAFKA_BROKERS
+// when set, for numbers against a real cluster, and otherwise an in-process
+// mock broker that acknowledges every request.
+func benchBrokers(b *testing.B) []string {
+	if brokers := os.Getenv("BENCH_KAFKA_BROKERS"); brokers != "" {
+		return strings.Split(brokers, ",")
+	}
+
+	broker := sarama.NewMockBroker(b, 1)
+	b.Cleanup(broker.Close)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(b),
+		"MetadataRequest": sarama.NewMockMetadataResponse(b).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(benchTopic, 0, broker.BrokerID()),
+		"ProduceRequest": sarama.NewMockProduceResponse(b),
+	})
+	return []string{broker.Addr()}
+}
+
+func benchProducer(b *testing.B, async bool) Producer {
+	b.Helper()
+	c := &conf.Data{Kafka: &conf.Data_Kafka{
+		Brokers:      benchBrokers(b),
+		RequiredAcks: int32(sarama.WaitForLocal),
+		Async:        &conf.Data_Kafka_Async{Enabled: async},
+	}}
+	producer, err := NewProducer(c, log.NewFilter(log.DefaultLogger, log.FilterLevel(log.LevelError)))
+	if err != nil {
+		b.Fatalf("failed to create producer: %v", err)
+	}
+	b.Cleanup(func() { _ = producer.Close() })
+	return producer
+}
+
+// BenchmarkProducer compares blocking sends on the sync and async producers,
+// as concurrent API requests make them, with fire-and-callback sends.
+func BenchmarkProducer(b *testing.B) {
+	payload := make([]byte, 512)
+	for i := range payload {
+		payload[i] = byte('a' + i%26)
+	}
+
+	for _, mode := range []struct {
+		name  string
+		async bool
+	}{
+		{name: "sync"},
+		{name: "async", async: true},
+	} {
+		b.Run(mode.name, func(b *testing.B) {
+			producer := benchProducer(b, mode.async)
+			b.SetBytes(int64(len(payload)))
+			b.ResetTimer()
+
+			var failed int64
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if err := producer.SendMessage(benchTopic, nil, payload); err != nil {
+						atomic.AddInt64(&failed, 1)
+					}
+				}
+			})
+			b.ReportMetric(float64(failed), "failed")
+		})
+	}
+
+	b.Run("async-callback", func(b *testing.B) {
+		producer := benchProducer(b, true)
+		b.SetBytes(int64(len(payload)))
+		b.ResetTimer()
+
+		var failed int64
+		var wg sync.WaitGroup
+		wg.Add(b.N)
+		for i := 0; i < b.N; i++ {
+			done := func(err error) {
+				if err != nil {
+					atomic.AddInt64(&failed, 1)
+				}
+				wg.Done()
+			}
+			if err := producer.SendMessageAsync(benchTopic, nil, payload, done); err != nil {
+				done(err)
+			}
+		}
+		wg.Wait()
+		b.ReportMetric(float64(failed), "failed")
+	})
+}
@@ -2,4 +2,4 @@ package kafka
 
 import "github.com/google/wire"
 
-var ProviderSet = wire.NewSet(NewProducer)
+var ProviderSet = wire.NewSet(NewProducer, NewSubscriber)
@@ -0,0 +1,105 @@
+package kafka
+
+import (
+	"bank-ledger/internal/conf"
+	"context"
+	"sync"
+
+	"github.com/IBM/sarama"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Subscriber is a kafka subscriber interface. Every subscriber receives every
+// message published after it subscribes, independent of consumer groups.
+type Subscriber interface {
+	Subscribe(ctx context.Context, topic string, handler func(key, value []byte)) error
+	Close() error
+}
+
+type kafkaSubscriber struct {
+	consumer sarama.Consumer
+	log      *log.Helper
+}
+
+func NewSubscriber(c *conf.Data, logger log.Logger) (Subscriber, func(), error) {
+	l := log.NewHelper(log.With(logger, "module", "kafka/subscriber"))
+
+	config := sarama.NewConfig()
+	config.Consumer.Return.Errors = true
+	config.Consumer.Offsets.Initial = sarama.OffsetNewest
+
+	consumer, err := sarama.NewConsumer(c.Kafka.Brokers, config)
+	if err != nil {
+		l.Errorf("failed to create kafka subscriber: %v", err)
+		return nil, nil, err
+	}
+
+	l.Infof("kafka subscriber created successfully, brokers: %v", c.Kafka.Brokers)
+
+	s := &kafkaSubscriber{
+		consumer: consumer,
+		log:      l,
+	}
+	return s, func() {
+		_ = s.Close()
+	}, nil
+}
+
+// Subscribe consumes every partition of topic from the newest offset and
+// blocks until ctx is done.
+func (s *kafkaSubscriber) Subscribe(ctx context.Context, topic string, handler func(key, value []byte)) error {
+	partitions, err := s.consumer.Partitions(topic)
+	if err != nil {
+		s.log.Errorf("failed to list partitions of %s: %v", topic, err)
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, partition := range partitions {
+		pc, err := s.consumer.ConsumePartition(topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			s.log.Errorf("failed to consume partition %d of %s: %v", partition, topic, err)
+			cancel()
+			wg.Wait()
+			return err
+		}
+
+		wg.Add(1)
+		go func(pc sarama.PartitionConsumer) {
+			defer wg.Done()
+			defer pc.AsyncClose()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case msg, ok := <-pc.Messages():
+					if !ok {
+						return
+					}
+					handler(msg.Key, msg.Value)
+				case err, ok := <-pc.Errors():
+					if ok {
+						s.log.Errorf("subscriber error: %v", err)
+					}
+				}
+			}
+		}(pc)
+	}
+
+	s.log.Infof("subscribed to %s, partitions: %v", topic, partitions)
+	wg.Wait()
+	return nil
+}
+
+func (s *kafkaSubscriber) Close() error {
+	if err := s.consumer.Close(); err != nil {
+		s.log.Errorf("failed to close subscriber: %v", err)
+		return err
+	}
+
+	s.log.Info("subscriber closed successfully")
+	return nil
+}
@@ -0,0 +1,57 @@
+// Package metrics holds the Prometheus collectors exported by the ledger
+// processes. Collectors register with the default registry.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Results recorded for a consumed message. ResultFailed is an attempt that
+// failed the transaction for good; ResultError one left PROCESSING for retry.
+const (
+	ResultSucceeded    = "succeeded"
+	ResultFailed       = "failed"
+	ResultDeadLettered = "dead_lettered"
+	ResultDuplicate    = "duplicate"
+	ResultError        = "error"
+)
+
+// Kinds of per-account sequence anomalies detected by the consumer.
+const (
+	SequenceOutOfOrder = "out_of_order"
+	SequenceDuplicate  = "duplicate"
+	SequenceGap        = "gap"
+)
+
+var (
+	ConsumerMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "bank_ledger",
+		Subsystem: "consumer",
+		Name:      "messages_total",
+		Help:      "Messages handled by the transaction consumer by topic and result.",
+	}, []string{"topic", "result"})
+
+	ConsumerProcessingSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "bank_ledger",
+		Subsystem: "consumer",
+		Name:      "processing_seconds",
+		Help:      "Time spent handling a message by topic and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"topic", "result"})
+
+	ConsumerLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "bank_ledger",
+		Subsystem: "consumer",
+		Name:      "lag",
+		Help:      "Messages between the last handled offset and the partition high water mark.",
+	}, []string{"topic", "partition"})
+
+	ConsumerSequenceAnomalies = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "bank_ledger",
+		Subsystem: "consumer",
+		Name:      "sequence_anomalies_total",
+		Help:      "Transaction commands applied out of their per-account sequence, by kind.",
+	}, []string{"kind"})
+)
+
+func init() {
+	prometheus.MustRegister(ConsumerMessages, ConsumerProcessingSeconds, ConsumerLag, ConsumerSequenceAnomalies)
+}
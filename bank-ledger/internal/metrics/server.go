@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+const (
+	serverRequestsName = "bank_ledger_server_requests"
+	serverSecondsName  = "bank_ledger_server_request_duration"
+)
+
+var (
+	TransactionsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "bank_ledger",
+		Name:      "transactions_created_total",
+		Help:      "Transactions accepted by the API by type.",
+	}, []string{"type"})
+
+	TransactionAmount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "bank_ledger",
+		Name:      "transaction_amount_total",
+		Help:      "Sum of the amounts of transactions accepted by the API by type.",
+	}, []string{"type"})
+
+	StuckTransactions = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "bank_ledger",
+		Subsystem: "sweeper",
+		Name:      "stuck_transactions",
+		Help:      "Transactions past the sweeper threshold at the last sweep by status.",
+	}, []string{"status"})
+
+	SweptTransactions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "bank_ledger",
+		Subsystem: "sweeper",
+		Name:      "transactions_total",
+		Help:      "Stuck transactions handled by the sweeper by status and action.",
+	}, []string{"status", "action"})
+)
+
+// Actions taken by the sweeper on a stuck transaction.
+const (
+	SweepRepublished = "republished"
+	SweepFailed      = "failed"
+	SweepError       = "error"
+)
+
+func init() {
+	prometheus.MustRegister(TransactionsCreated, TransactionAmount, StuckTransactions, SweptTransactions)
+}
+
+// NewServerMiddleware returns the kratos metrics middleware recording request
+// counts by operation and status code, and request latency by operation. It
+// registers its exporter with the default Prometheus registry, so call it once
+// and share the result between servers.
+func NewServerMiddleware() (middleware.Middleware, error) {
+	exporter, err := otelprom.New()
+	if err != nil {
+		return nil, err
+	}
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(exporter),
+		sdkmetric.WithView(metrics.DefaultSecondsHistogramView(serverSecondsName)),
+	)
+	meter := provider.Meter("bank-ledger")
+
+	requests, err := metrics.DefaultRequestsCounter(meter, serverRequestsName)
+	if err != nil {
+		return nil, err
+	}
+	seconds, err := metrics.DefaultSecondsHistogram(meter, serverSecondsName)
+	if err != nil {
+		return nil, err
+	}
+	return metrics.Server(metrics.WithRequests(requests), metrics.WithSeconds(seconds)), nil
+}
+
+// RegisterOpenAccounts exports the number of open accounts, read from count on
+// every scrape.
+func RegisterOpenAccounts(count func(ctx context.Context) (int64, error)) error {
+	return prometheus.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: "bank_ledger",
+		Name:      "open_accounts",
+		Help:      "Accounts currently open.",
+	}, func() float64 {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		n, err := count(ctx)
+		if err != nil {
+			return 0
+		}
+		return float64(n)
+	}))
+}
@@ -0,0 +1,57 @@
+// Package propagation carries request-scoped identifiers from the API edge
+// through Kafka into the consumer.
+package propagation
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Values are the identifiers propagated with a request.
+type Values struct {
+	RequestID     string
+	CorrelationID string
+	// TraceParent and TraceState are the W3C trace context headers.
+	TraceParent string
+	TraceState  string
+}
+
+type valuesKey struct{}
+
+// NewContext returns a copy of ctx carrying v.
+func NewContext(ctx context.Context, v Values) context.Context {
+	return context.WithValue(ctx, valuesKey{}, v)
+}
+
+// FromContext returns the values carried by ctx.
+func FromContext(ctx context.Context) (Values, bool) {
+	v, ok := ctx.Value(valuesKey{}).(Values)
+	return v, ok
+}
+
+// TraceID returns the trace id of the W3C traceparent, or "" when absent or malformed.
+func (v Values) TraceID() string {
+	parts := strings.Split(v.TraceParent, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 {
+		return ""
+	}
+	return parts[1]
+}
+
+// RequestID returns a log valuer for the request id in ctx.
+func RequestID() log.Valuer {
+	return func(ctx context.Context) interface{} {
+		v, _ := FromContext(ctx)
+		return v.RequestID
+	}
+}
+
+// CorrelationID returns a log valuer for the correlation id in ctx.
+func CorrelationID() log.Valuer {
+	return func(ctx context.Context) interface{} {
+		v, _ := FromContext(ctx)
+		return v.CorrelationID
+	}
+}
@@ -0,0 +1,23 @@
+package server
+
+import (
+	"bank-ledger/internal/biz"
+	"bank-ledger/internal/conf"
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// BatchWorker periodically publishes pending batch lines as transactions.
+type BatchWorker struct {
+	*periodicWorker
+}
+
+// NewBatchWorker new a batch worker.
+func NewBatchWorker(c *conf.Server, batches biz.BatchHandler, logger log.Logger) *BatchWorker {
+	job := func(ctx context.Context, limit int) (int, error) {
+		return batches.ProcessPending(ctx, limit)
+	}
+	return &BatchWorker{newPeriodicWorker("batch", c.GetBatch(), 5*time.Second, 500, job, logger)}
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"bank-ledger/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// NewConsumerHTTPServer new the consumer's HTTP server, serving /healthz,
+// /readyz and Prometheus metrics on /metrics.
+func NewConsumerHTTPServer(c *conf.Consumer, health *Health, logger log.Logger) *http.Server {
+	var opts = []http.ServerOption{
+		http.Middleware(
+			recovery.Recovery(),
+		),
+	}
+	if c.Http.Network != "" {
+		opts = append(opts, http.Network(c.Http.Network))
+	}
+	if c.Http.Addr != "" {
+		opts = append(opts, http.Address(c.Http.Addr))
+	}
+	if c.Http.Timeout != nil {
+		opts = append(opts, http.Timeout(c.Http.Timeout.AsDuration()))
+	}
+	srv := http.NewServer(opts...)
+	srv.HandleFunc("/healthz", health.liveness)
+	srv.HandleFunc("/readyz", health.readiness)
+	srv.Handle("/metrics", promhttp.Handler())
+	return srv
+}
+
+// NewConsumerGRPCServer new the consumer's gRPC server, serving the standard
+// health service backed by the readiness checks.
+func NewConsumerGRPCServer(c *conf.Consumer, health *Health, logger log.Logger) *grpc.Server {
+	var opts = []grpc.ServerOption{
+		grpc.Middleware(
+			recovery.Recovery(),
+		),
+		grpc.CustomHealth(),
+	}
+	if c.Grpc.Network != "" {
+		opts = append(opts, grpc.Network(c.Grpc.Network))
+	}
+	if c.Grpc.Addr != "" {
+		opts = append(opts, grpc.Address(c.Grpc.Addr))
+	}
+	if c.Grpc.Timeout != nil {
+		opts = append(opts, grpc.Timeout(c.Grpc.Timeout.AsDuration()))
+	}
+	srv := grpc.NewServer(opts...)
+	grpc_health_v1.RegisterHealthServer(srv, health)
+	return srv
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"bank-ledger/internal/biz"
+	"bank-ledger/internal/kafka"
+	"bank-ledger/internal/service"
+	"context"
+	"encoding/json"
+	"fmt"
+	nethttp "net/http"
+	"sync"
+	"time"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// sseHeartbeat keeps idle event streams alive and detects disconnected clients.
+const sseHeartbeat = 15 * time.Second
+
+// TransactionEventListener feeds status-change events published by the
+// consumer into the transaction watcher.
+type TransactionEventListener struct {
+	topic      string
+	subscriber kafka.Subscriber
+	watcher    biz.TransactionWatcher
+	log        *log.Helper
+	cancel     context.CancelFunc
+}
+
+// NewTransactionEventListener new a transaction event listener.
+func NewTransactionEventListener(topics *biz.Topics, subscriber kafka.Subscriber, watcher biz.TransactionWatcher, logger log.Logger) *TransactionEventListener {
+	return &TransactionEventListener{
+		topic:      topics.TransactionStatus,
+		subscriber: subscriber,
+		watcher:    watcher,
+		log:        log.NewHelper(log.With(logger, "module", "server/events")),
+	}
+}
+
+func (l *TransactionEventListener) Start(ctx context.Context) error {
+	ctx, l.cancel = context.WithCancel(ctx)
+	l.log.Infof("transaction event listener started, topic: %s", l.topic)
+	for {
+		if err := l.subscriber.Subscribe(ctx, l.topic, l.watcher.Publish); err != nil {
+			l.log.Errorf("failed to subscribe to transaction events: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func (l *TransactionEventListener) Stop(ctx context.Context) error {
+	if l.cancel != nil {
+		l.cancel()
+	}
+	l.log.Info("transaction event listener stopped")
+	return nil
+}
+
+// transactionEventsHandler serves WatchTransaction as server-sent events.
+func transactionEventsHandler(transactionService *service.TransactionService) http.HandlerFunc {
+	return func(ctx http.Context) error {
+		w := ctx.Response()
+		flusher, ok := w.(nethttp.Flusher)
+		if !ok {
+			return fmt.Errorf("streaming is not supported")
+		}
+
+		// The server timeout applies to every route, so detach from it and rely
+		// on heartbeat write failures to notice a disconnected client.
+		streamCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		defer cancel()
+
+		var mu sync.Mutex
+		started := false
+		write := func(event string, payload []byte) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if !started {
+				w.Header().Set("Content-Type", "text/event-stream")
+				w.Header().Set("Cache-Control", "no-cache")
+				w.Header().Set("Connection", "keep-alive")
+				w.WriteHeader(nethttp.StatusOK)
+				started = true
+			}
+			var err error
+			if event == "" {
+				_, err = fmt.Fprint(w, ": heartbeat\n\n")
+			} else {
+				_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+			}
+			if err != nil {
+				cancel()
+				return err
+			}
+			flusher.Flush()
+			return nil
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			ticker := time.NewTicker(sseHeartbeat)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-streamCtx.Done():
+					return
+				case <-ticker.C:
+					_ = write("", nil)
+				}
+			}
+		}()
+
+		req := &v1.WatchTransactionRequest{TransactionId: ctx.Vars().Get("transaction_id")}
+		err := transactionService.Watch(streamCtx, req, func(event *v1.TransactionEvent) error {
+			payload, err := protojson.Marshal(event)
+			if err != nil {
+				return err
+			}
+			return write("status", payload)
+		})
+		cancel()
+		<-done
+
+		if err != nil && !started {
+			return err
+		}
+		if err != nil {
+			payload, _ := json.Marshal(errors.FromError(err))
+			_ = write("error", payload)
+		}
+		return nil
+	}
+}
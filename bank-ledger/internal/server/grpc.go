@@ -6,15 +6,20 @@ import (
 	"bank-ledger/internal/service"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 )
 
 // NewGRPCServer new a gRPC server.
-func NewGRPCServer(c *conf.Server, accountService *service.AccountService, transactionService *service.TransactionService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Server, accountService *service.AccountService, transactionService *service.TransactionService, scheduleService *service.ScheduleService, batchService *service.BatchService, webhookService *service.WebhookService, metricsMiddleware middleware.Middleware, logger log.Logger) *grpc.Server {
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(),
+			tracing.Server(),
+			requestMetadata(),
+			metricsMiddleware,
 		),
 	}
 	if c.Grpc.Network != "" {
@@ -29,5 +34,8 @@ func NewGRPCServer(c *conf.Server, accountService *service.AccountService, trans
 	srv := grpc.NewServer(opts...)
 	v1.RegisterAccountServer(srv, accountService)
 	v1.RegisterTransactionServer(srv, transactionService)
+	v1.RegisterScheduleServer(srv, scheduleService)
+	v1.RegisterBatchServer(srv, batchService)
+	v1.RegisterWebhookServer(srv, webhookService)
 	return srv
 }
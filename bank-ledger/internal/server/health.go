@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// readinessTimeout bounds a full round of readiness checks.
+const readinessTimeout = 3 * time.Second
+
+// HealthCheck reports whether a dependency is reachable.
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// Health answers liveness and readiness probes over HTTP and the standard
+// gRPC health service. The process is live while it serves requests and ready
+// while every check passes.
+type Health struct {
+	grpc_health_v1.UnimplementedHealthServer
+	checks []HealthCheck
+}
+
+func NewHealth(checks ...HealthCheck) *Health {
+	return &Health{checks: checks}
+}
+
+// Ready runs every check and returns each one's result.
+func (h *Health) Ready(ctx context.Context) (map[string]string, bool) {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	results := make(map[string]string, len(h.checks))
+	ready := true
+	for _, check := range h.checks {
+		if err := check.Check(ctx); err != nil {
+			results[check.Name] = err.Error()
+			ready = false
+			continue
+		}
+		results[check.Name] = "ok"
+	}
+	return results, ready
+}
+
+func (h *Health) liveness(w nethttp.ResponseWriter, r *nethttp.Request) {
+	writeHealth(w, nethttp.StatusOK, map[string]interface{}{"status": "ok"})
+}
+
+func (h *Health) readiness(w nethttp.ResponseWriter, r *nethttp.Request) {
+	results, ready := h.Ready(r.Context())
+	if !ready {
+		writeHealth(w, nethttp.StatusServiceUnavailable, map[string]interface{}{"status": "unavailable", "checks": results})
+		return
+	}
+	writeHealth(w, nethttp.StatusOK, map[string]interface{}{"status": "ready", "checks": results})
+}
+
+func writeHealth(w nethttp.ResponseWriter, code int, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// Check implements grpc_health_v1.HealthServer using the readiness checks.
+func (h *Health) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if req.Service != "" {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+	}
+	if _, ready := h.Ready(ctx); !ready {
+		return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
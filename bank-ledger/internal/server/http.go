@@ -6,16 +6,23 @@ import (
 	"bank-ledger/internal/service"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // NewHTTPServer new an HTTP server.
-func NewHTTPServer(c *conf.Server, accountService *service.AccountService, transactionService *service.TransactionService, logger log.Logger) *http.Server {
+func NewHTTPServer(c *conf.Server, accountService *service.AccountService, transactionService *service.TransactionService, scheduleService *service.ScheduleService, batchService *service.BatchService, webhookService *service.WebhookService, metricsMiddleware middleware.Middleware, logger log.Logger) *http.Server {
 	var opts = []http.ServerOption{
 		http.Middleware(
 			recovery.Recovery(),
+			tracing.Server(),
+			requestMetadata(),
+			metricsMiddleware,
 		),
+		http.ResponseEncoder(statementResponseEncoder),
 	}
 	if c.Http.Network != "" {
 		opts = append(opts, http.Network(c.Http.Network))
@@ -29,5 +36,10 @@ func NewHTTPServer(c *conf.Server, accountService *service.AccountService, trans
 	srv := http.NewServer(opts...)
 	v1.RegisterAccountHTTPServer(srv, accountService)
 	v1.RegisterTransactionHTTPServer(srv, transactionService)
+	srv.Route("/").GET("/v1/transaction/{transaction_id}/events", transactionEventsHandler(transactionService))
+	v1.RegisterScheduleHTTPServer(srv, scheduleService)
+	v1.RegisterBatchHTTPServer(srv, batchService)
+	v1.RegisterWebhookHTTPServer(srv, webhookService)
+	srv.Handle("/metrics", promhttp.Handler())
 	return srv
 }
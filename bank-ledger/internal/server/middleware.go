@@ -0,0 +1,48 @@
+package server
+
+import (
+	"bank-ledger/internal/propagation"
+	"context"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/rs/xid"
+)
+
+const (
+	requestIDHeader     = "X-Request-ID"
+	correlationIDHeader = "X-Correlation-ID"
+	traceParentHeader   = "traceparent"
+	traceStateHeader    = "tracestate"
+)
+
+// requestMetadata reads or assigns the request and correlation ids and the
+// trace context of an incoming call, echoes the ids back to the caller and
+// stores them in the context for propagation into Kafka.
+func requestMetadata() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			v := propagation.Values{
+				RequestID:     tr.RequestHeader().Get(requestIDHeader),
+				CorrelationID: tr.RequestHeader().Get(correlationIDHeader),
+				TraceParent:   tr.RequestHeader().Get(traceParentHeader),
+				TraceState:    tr.RequestHeader().Get(traceStateHeader),
+			}
+			if v.RequestID == "" {
+				v.RequestID = xid.New().String()
+			}
+			if v.CorrelationID == "" {
+				v.CorrelationID = v.RequestID
+			}
+			tr.ReplyHeader().Set(requestIDHeader, v.RequestID)
+			tr.ReplyHeader().Set(correlationIDHeader, v.CorrelationID)
+
+			return handler(propagation.NewContext(ctx, v), req)
+		}
+	}
+}
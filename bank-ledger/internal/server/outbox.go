@@ -0,0 +1,23 @@
+package server
+
+import (
+	"bank-ledger/internal/biz"
+	"bank-ledger/internal/conf"
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// OutboxWorker periodically relays unpublished outbox events to Kafka.
+type OutboxWorker struct {
+	*periodicWorker
+}
+
+// NewOutboxWorker new an outbox worker.
+func NewOutboxWorker(c *conf.Server, outbox biz.OutboxRelay, logger log.Logger) *OutboxWorker {
+	job := func(ctx context.Context, limit int) (int, error) {
+		return outbox.PublishPending(ctx, limit)
+	}
+	return &OutboxWorker{newPeriodicWorker("outbox", c.GetOutbox(), time.Second, 500, job, logger)}
+}
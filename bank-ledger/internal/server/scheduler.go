@@ -0,0 +1,23 @@
+package server
+
+import (
+	"bank-ledger/internal/biz"
+	"bank-ledger/internal/conf"
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// ScheduleWorker periodically enqueues due scheduled transactions.
+type ScheduleWorker struct {
+	*periodicWorker
+}
+
+// NewScheduleWorker new a schedule worker.
+func NewScheduleWorker(c *conf.Server, schedules biz.ScheduleHandler, logger log.Logger) *ScheduleWorker {
+	job := func(ctx context.Context, limit int) (int, error) {
+		return schedules.RunDue(ctx, time.Now(), limit)
+	}
+	return &ScheduleWorker{newPeriodicWorker("scheduler", c.GetScheduler(), 10*time.Second, 100, job, logger)}
+}
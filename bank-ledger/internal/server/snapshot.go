@@ -0,0 +1,24 @@
+package server
+
+import (
+	"bank-ledger/internal/biz"
+	"bank-ledger/internal/conf"
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// SnapshotWorker periodically takes end-of-day balance snapshots of accounts
+// that are missing one.
+type SnapshotWorker struct {
+	*periodicWorker
+}
+
+// NewSnapshotWorker new a balance snapshot worker.
+func NewSnapshotWorker(c *conf.Server, snapshots biz.SnapshotHandler, logger log.Logger) *SnapshotWorker {
+	job := func(ctx context.Context, limit int) (int, error) {
+		return snapshots.TakeDue(ctx, time.Now(), limit)
+	}
+	return &SnapshotWorker{newPeriodicWorker("snapshot", c.GetSnapshot(), 10*time.Minute, 200, job, logger)}
+}
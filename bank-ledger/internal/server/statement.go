@@ -0,0 +1,305 @@
+package server
+
+import (
+	v1 "bank-ledger/api/bankLedger/v1"
+	"bank-ledger/internal/biz"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/transport/http"
+)
+
+const ofxTimeLayout = "20060102150405"
+
+// statementResponseEncoder renders statements in the format requested through
+// the format query parameter and falls back to the default encoder otherwise.
+func statementResponseEncoder(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	statement, ok := v.(*v1.GetStatementResponse)
+	if !ok {
+		return http.DefaultResponseEncoder(w, r, v)
+	}
+
+	var (
+		contentType string
+		extension   string
+		render      func(io.Writer, *v1.GetStatementResponse) error
+	)
+	switch r.URL.Query().Get("format") {
+	case biz.StatementFormatCSV:
+		contentType, extension, render = "text/csv", "csv", writeStatementCSV
+	case biz.StatementFormatOFX:
+		contentType, extension, render = "application/x-ofx", "ofx", writeStatementOFX
+	case biz.StatementFormatCAMT053:
+		contentType, extension, render = "application/xml", "xml", writeStatementCAMT053
+	default:
+		return http.DefaultResponseEncoder(w, r, v)
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"statement-%s.%s\"", statement.AccountNumber, extension))
+	return render(w, statement)
+}
+
+func writeStatementCSV(w io.Writer, statement *v1.GetStatementResponse) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"booked_at", "transaction_id", "type", "description", "amount", "currency", "running_balance"}); err != nil {
+		return err
+	}
+	for _, line := range statement.Lines {
+		if err := cw.Write([]string{
+			line.BookedAt,
+			line.TransactionId,
+			line.Type.String(),
+			line.Description,
+			formatAmount(line.Amount),
+			statement.Currency,
+			formatAmount(line.RunningBalance),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+type ofxDocument struct {
+	XMLName xml.Name `xml:"OFX"`
+	SignOn  struct {
+		Response struct {
+			Status   ofxStatus `xml:"STATUS"`
+			DTServer string    `xml:"DTSERVER"`
+			Language string    `xml:"LANGUAGE"`
+		} `xml:"SONRS"`
+	} `xml:"SIGNONMSGSRSV1"`
+	Bank struct {
+		Transaction struct {
+			TrnUID    string          `xml:"TRNUID"`
+			Status    ofxStatus       `xml:"STATUS"`
+			Statement ofxStatementRes `xml:"STMTRS"`
+		} `xml:"STMTTRNRS"`
+	} `xml:"BANKMSGSRSV1"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxStatementRes struct {
+	Currency string `xml:"CURDEF"`
+	Account  struct {
+		BankID   string `xml:"BANKID"`
+		AcctID   string `xml:"ACCTID"`
+		AcctType string `xml:"ACCTTYPE"`
+	} `xml:"BANKACCTFROM"`
+	TranList struct {
+		DTStart      string           `xml:"DTSTART"`
+		DTEnd        string           `xml:"DTEND"`
+		Transactions []ofxTransaction `xml:"STMTTRN"`
+	} `xml:"BANKTRANLIST"`
+	LedgerBalance struct {
+		Amount string `xml:"BALAMT"`
+		DTAsOf string `xml:"DTASOF"`
+	} `xml:"LEDGERBAL"`
+}
+
+type ofxTransaction struct {
+	Type     string `xml:"TRNTYPE"`
+	DTPosted string `xml:"DTPOSTED"`
+	Amount   string `xml:"TRNAMT"`
+	FitID    string `xml:"FITID"`
+	Memo     string `xml:"MEMO,omitempty"`
+}
+
+func writeStatementOFX(w io.Writer, statement *v1.GetStatementResponse) error {
+	var doc ofxDocument
+	doc.SignOn.Response.Status = ofxStatus{Code: 0, Severity: "INFO"}
+	doc.SignOn.Response.DTServer = time.Now().Format(ofxTimeLayout)
+	doc.SignOn.Response.Language = "ENG"
+
+	tr := &doc.Bank.Transaction
+	tr.TrnUID = statement.AccountId
+	tr.Status = ofxStatus{Code: 0, Severity: "INFO"}
+
+	stmt := &tr.Statement
+	stmt.Currency = statement.Currency
+	stmt.Account.BankID = "BANKLEDGER"
+	stmt.Account.AcctID = statement.AccountNumber
+	stmt.Account.AcctType = "CHECKING"
+	stmt.TranList.DTStart = ofxTime(statement.From)
+	stmt.TranList.DTEnd = ofxTime(statement.To)
+	for _, line := range statement.Lines {
+		stmt.TranList.Transactions = append(stmt.TranList.Transactions, ofxTransaction{
+			Type:     ofxTransactionType(line),
+			DTPosted: ofxTime(line.BookedAt),
+			Amount:   formatAmount(line.Amount),
+			FitID:    line.TransactionId,
+			Memo:     line.Description,
+		})
+	}
+	stmt.LedgerBalance.Amount = formatAmount(statement.ClosingBalance)
+	stmt.LedgerBalance.DTAsOf = ofxTime(statement.To)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, `<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>`+"\n"); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(doc)
+}
+
+func ofxTransactionType(line *v1.StatementLine) string {
+	switch line.Type {
+	case v1.TransactionType_FEE:
+		return "FEE"
+	case v1.TransactionType_TRANSFER:
+		return "XFER"
+	}
+	if line.Amount < 0 {
+		return "DEBIT"
+	}
+	return "CREDIT"
+}
+
+func ofxTime(ts string) string {
+	parsed, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return ""
+	}
+	return parsed.Format(ofxTimeLayout)
+}
+
+type camtDocument struct {
+	XMLName   xml.Name `xml:"urn:iso:std:iso:20022:tech:xsd:camt.053.001.02 Document"`
+	Statement struct {
+		GroupHeader struct {
+			MsgID   string `xml:"MsgId"`
+			CreDtTm string `xml:"CreDtTm"`
+		} `xml:"GrpHdr"`
+		Stmt camtStatement `xml:"Stmt"`
+	} `xml:"BkToCstmrStmt"`
+}
+
+type camtStatement struct {
+	ID       string `xml:"Id"`
+	CreDtTm  string `xml:"CreDtTm"`
+	FromToDt struct {
+		From string `xml:"FrDtTm"`
+		To   string `xml:"ToDtTm"`
+	} `xml:"FrToDt"`
+	Account struct {
+		ID       string `xml:"Id>Othr>Id"`
+		Currency string `xml:"Ccy"`
+		Name     string `xml:"Nm,omitempty"`
+	} `xml:"Acct"`
+	Balances []camtBalance `xml:"Bal"`
+	Summary  struct {
+		Credits camtEntrySummary `xml:"TtlCdtNtries"`
+		Debits  camtEntrySummary `xml:"TtlDbtNtries"`
+	} `xml:"TxsSummry"`
+	Entries []camtEntry `xml:"Ntry"`
+}
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtBalance struct {
+	Code      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	CdtDbtInd string     `xml:"CdtDbtInd"`
+	DateTime  string     `xml:"Dt>DtTm"`
+}
+
+type camtEntrySummary struct {
+	Count int    `xml:"NbOfNtries"`
+	Sum   string `xml:"Sum"`
+}
+
+type camtEntry struct {
+	Ref         string     `xml:"NtryRef"`
+	Amount      camtAmount `xml:"Amt"`
+	CdtDbtInd   string     `xml:"CdtDbtInd"`
+	Status      string     `xml:"Sts"`
+	BookingDate string     `xml:"BookgDt>DtTm"`
+	BankTxCode  string     `xml:"BkTxCd>Prtry>Cd"`
+	EndToEndID  string     `xml:"NtryDtls>TxDtls>Refs>EndToEndId"`
+	Info        string     `xml:"AddtlNtryInf,omitempty"`
+}
+
+func writeStatementCAMT053(w io.Writer, statement *v1.GetStatementResponse) error {
+	now := time.Now().Format(time.RFC3339)
+
+	var doc camtDocument
+	doc.Statement.GroupHeader.MsgID = fmt.Sprintf("STMT-%s-%d", statement.AccountNumber, time.Now().Unix())
+	doc.Statement.GroupHeader.CreDtTm = now
+
+	stmt := &doc.Statement.Stmt
+	stmt.ID = doc.Statement.GroupHeader.MsgID
+	stmt.CreDtTm = now
+	stmt.FromToDt.From = statement.From
+	stmt.FromToDt.To = statement.To
+	stmt.Account.ID = statement.AccountNumber
+	stmt.Account.Currency = statement.Currency
+	stmt.Account.Name = statement.AccountName
+	stmt.Balances = []camtBalance{
+		camtBalanceOf("OPBD", statement.OpeningBalance, statement.Currency, statement.From),
+		camtBalanceOf("CLBD", statement.ClosingBalance, statement.Currency, statement.To),
+	}
+
+	for _, line := range statement.Lines {
+		if line.Amount >= 0 {
+			stmt.Summary.Credits.Count++
+		} else {
+			stmt.Summary.Debits.Count++
+		}
+		stmt.Entries = append(stmt.Entries, camtEntry{
+			Ref:         line.TransactionId,
+			Amount:      camtAmount{Currency: statement.Currency, Value: formatAmount(math.Abs(line.Amount))},
+			CdtDbtInd:   creditDebitIndicator(line.Amount),
+			Status:      "BOOK",
+			BookingDate: line.BookedAt,
+			BankTxCode:  line.Type.String(),
+			EndToEndID:  line.TransactionId,
+			Info:        line.Description,
+		})
+	}
+	stmt.Summary.Credits.Sum = formatAmount(statement.TotalCredits)
+	stmt.Summary.Debits.Sum = formatAmount(statement.TotalDebits)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(doc)
+}
+
+func camtBalanceOf(code string, amount float64, currency string, at string) camtBalance {
+	return camtBalance{
+		Code:      code,
+		Amount:    camtAmount{Currency: currency, Value: formatAmount(math.Abs(amount))},
+		CdtDbtInd: creditDebitIndicator(amount),
+		DateTime:  at,
+	}
+}
+
+func creditDebitIndicator(amount float64) string {
+	if amount < 0 {
+		return "DBIT"
+	}
+	return "CRDT"
+}
+
+func formatAmount(val float64) string {
+	return strconv.FormatFloat(val, 'f', 2, 64)
+}
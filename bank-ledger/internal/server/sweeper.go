@@ -0,0 +1,30 @@
+package server
+
+import (
+	"bank-ledger/internal/biz"
+	"bank-ledger/internal/conf"
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// SweeperWorker periodically republishes or fails transactions stuck in
+// INITIATED or PROCESSING.
+type SweeperWorker struct {
+	*periodicWorker
+}
+
+// NewSweeperWorker new a stuck-transaction sweeper worker.
+func NewSweeperWorker(c *conf.Server, sweeper biz.TransactionSweeper, logger log.Logger) *SweeperWorker {
+	w := &SweeperWorker{}
+	job := func(ctx context.Context, limit int) (int, error) {
+		swept, err := sweeper.Sweep(ctx, limit)
+		if swept > 0 {
+			w.log.Infof("swept %d stuck transactions", swept)
+		}
+		return swept, err
+	}
+	w.periodicWorker = newPeriodicWorker("sweeper", c.GetSweeper(), time.Minute, 100, job, logger)
+	return w
+}
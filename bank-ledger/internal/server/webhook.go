@@ -0,0 +1,23 @@
+package server
+
+import (
+	"bank-ledger/internal/biz"
+	"bank-ledger/internal/conf"
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// WebhookWorker periodically sends due webhook deliveries.
+type WebhookWorker struct {
+	*periodicWorker
+}
+
+// NewWebhookWorker new a webhook worker.
+func NewWebhookWorker(c *conf.Server, webhooks biz.WebhookHandler, logger log.Logger) *WebhookWorker {
+	job := func(ctx context.Context, limit int) (int, error) {
+		return webhooks.DeliverDue(ctx, time.Now(), limit)
+	}
+	return &WebhookWorker{newPeriodicWorker("webhook", c.GetWebhook(), 5*time.Second, 100, job, logger)}
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// periodicJob processes up to limit items and returns how many it processed.
+type periodicJob func(ctx context.Context, limit int) (int, error)
+
+// workerConfig is the interval and batch size every worker section of
+// conf.Server carries.
+type workerConfig interface {
+	GetInterval() *durationpb.Duration
+	GetBatchSize() int32
+}
+
+// periodicWorker runs a job every interval. Each tick drains the backlog: the
+// job runs again while it fills a whole batch, until it fails or the worker
+// is stopped.
+type periodicWorker struct {
+	name      string
+	job       periodicJob
+	interval  time.Duration
+	batchSize int
+	log       *log.Helper
+	stop      chan struct{}
+}
+
+// newPeriodicWorker new a periodic worker, taking the interval and batch size
+// from c when set.
+func newPeriodicWorker(name string, c workerConfig, interval time.Duration, batchSize int, job periodicJob, logger log.Logger) *periodicWorker {
+	w := &periodicWorker{
+		name:      name,
+		job:       job,
+		interval:  interval,
+		batchSize: batchSize,
+		log:       log.NewHelper(log.With(logger, "module", "server/"+name)),
+		stop:      make(chan struct{}),
+	}
+	if c.GetInterval() != nil {
+		w.interval = c.GetInterval().AsDuration()
+	}
+	if c.GetBatchSize() > 0 {
+		w.batchSize = int(c.GetBatchSize())
+	}
+	return w
+}
+
+func (w *periodicWorker) Start(ctx context.Context) error {
+	w.log.Infof("%s worker started, interval: %s", w.name, w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		w.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *periodicWorker) drain(ctx context.Context) {
+	for {
+		processed, err := w.job(ctx, w.batchSize)
+		if err != nil {
+			w.log.Errorf("%s worker run failed: %v", w.name, err)
+			return
+		}
+		if processed < w.batchSize {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stop:
+			return
+		default:
+		}
+	}
+}
+
+func (w *periodicWorker) Stop(ctx context.Context) error {
+	close(w.stop)
+	w.log.Infof("%s worker stopped", w.name)
+	return nil
+}
@@ -9,11 +9,12 @@ import (
 
 type AccountService struct {
 	v1.UnimplementedAccountServer
-	uc biz.AccountHandler
+	uc        biz.AccountHandler
+	snapshots biz.SnapshotHandler
 }
 
-func NewAccountService(uc biz.AccountHandler) *AccountService {
-	return &AccountService{uc: uc}
+func NewAccountService(uc biz.AccountHandler, snapshots biz.SnapshotHandler) *AccountService {
+	return &AccountService{uc: uc, snapshots: snapshots}
 }
 
 func (s *AccountService) CreateAccount(ctx context.Context, req *v1.CreateAccountRequest) (*v1.AccountResponse, error) {
@@ -32,15 +33,12 @@ func (s *AccountService) GetAccount(ctx context.Context, req *v1.BaseRequest) (*
 	return account, nil
 }
 
-func (s *AccountService) GetAllAccounts(ctx context.Context, req *v1.EmptyRequest) (*v1.GetAllAccountsResponse, error) {
-	accounts, err := s.uc.ListAll(ctx)
+func (s *AccountService) GetAllAccounts(ctx context.Context, req *v1.ListAccountsRequest) (*v1.GetAllAccountsResponse, error) {
+	accounts, err := s.uc.List(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-
-	return &v1.GetAllAccountsResponse{
-		Accounts: accounts,
-	}, nil
+	return accounts, nil
 }
 
 func (s *AccountService) UpdateAccount(ctx context.Context, req *v1.UpdateAccountRequest) (*v1.AccountResponse, error) {
@@ -58,3 +56,11 @@ func (s *AccountService) DeleteAccount(ctx context.Context, req *v1.BaseRequest)
 	}
 	return &v1.DeleteAccountResponse{Success: true}, nil
 }
+
+func (s *AccountService) GetBalance(ctx context.Context, req *v1.GetBalanceRequest) (*v1.BalanceResponse, error) {
+	balance, err := s.snapshots.GetBalance(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"bank-ledger/internal/biz"
+)
+
+type BatchService struct {
+	v1.UnimplementedBatchServer
+	bat biz.BatchHandler
+}
+
+func NewBatchService(bat biz.BatchHandler) *BatchService {
+	return &BatchService{bat: bat}
+}
+
+func (s *BatchService) CreateBatch(ctx context.Context, req *v1.CreateBatchRequest) (*v1.BatchResponse, error) {
+	batch, err := s.bat.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+func (s *BatchService) GetBatch(ctx context.Context, req *v1.BaseRequest) (*v1.BatchResponse, error) {
+	batch, err := s.bat.FindByID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
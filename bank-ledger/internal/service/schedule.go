@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"bank-ledger/internal/biz"
+)
+
+type ScheduleService struct {
+	v1.UnimplementedScheduleServer
+	sch biz.ScheduleHandler
+}
+
+func NewScheduleService(sch biz.ScheduleHandler) *ScheduleService {
+	return &ScheduleService{sch: sch}
+}
+
+func (s *ScheduleService) CreateSchedule(ctx context.Context, req *v1.CreateScheduleRequest) (*v1.ScheduleResponse, error) {
+	schedule, err := s.sch.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (s *ScheduleService) GetSchedule(ctx context.Context, req *v1.BaseRequest) (*v1.ScheduleResponse, error) {
+	schedule, err := s.sch.FindByID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (s *ScheduleService) ListSchedules(ctx context.Context, req *v1.ListSchedulesRequest) (*v1.ListSchedulesResponse, error) {
+	schedules, err := s.sch.ListByAccount(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (s *ScheduleService) PauseSchedule(ctx context.Context, req *v1.BaseRequest) (*v1.ScheduleResponse, error) {
+	schedule, err := s.sch.Pause(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (s *ScheduleService) ResumeSchedule(ctx context.Context, req *v1.BaseRequest) (*v1.ScheduleResponse, error) {
+	schedule, err := s.sch.Resume(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (s *ScheduleService) CancelSchedule(ctx context.Context, req *v1.BaseRequest) (*v1.ScheduleResponse, error) {
+	schedule, err := s.sch.Cancel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
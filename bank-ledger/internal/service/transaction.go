@@ -9,11 +9,12 @@ import (
 
 type TransactionService struct {
 	v1.UnimplementedTransactionServer
-	trx biz.TransactionHandler
+	trx   biz.TransactionHandler
+	watch biz.TransactionWatcher
 }
 
-func NewTransactionService(trx biz.TransactionHandler) *TransactionService {
-	return &TransactionService{trx: trx}
+func NewTransactionService(trx biz.TransactionHandler, watch biz.TransactionWatcher) *TransactionService {
+	return &TransactionService{trx: trx, watch: watch}
 }
 
 func (s *TransactionService) CreateTransaction(ctx context.Context, req *v1.CreateTransactionRequest) (*v1.CreateTransactionResponse, error) {
@@ -42,3 +43,22 @@ func (s *TransactionService) GetTransactionsByAccount(ctx context.Context, req *
 
 	return transactions, nil
 }
+
+func (s *TransactionService) GetStatement(ctx context.Context, req *v1.GetStatementRequest) (*v1.GetStatementResponse, error) {
+	statement, err := s.trx.GetStatement(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return statement, nil
+}
+
+func (s *TransactionService) WatchTransaction(req *v1.WatchTransactionRequest, stream v1.Transaction_WatchTransactionServer) error {
+	return s.Watch(stream.Context(), req, stream.Send)
+}
+
+// Watch streams transaction events to send; it backs both the gRPC stream and
+// the HTTP server-sent events endpoint.
+func (s *TransactionService) Watch(ctx context.Context, req *v1.WatchTransactionRequest, send func(*v1.TransactionEvent) error) error {
+	return s.watch.Watch(ctx, req, send)
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	v1 "bank-ledger/api/bankLedger/v1"
+	"bank-ledger/internal/biz"
+)
+
+type WebhookService struct {
+	v1.UnimplementedWebhookServer
+	wh biz.WebhookHandler
+}
+
+func NewWebhookService(wh biz.WebhookHandler) *WebhookService {
+	return &WebhookService{wh: wh}
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, req *v1.CreateWebhookRequest) (*v1.WebhookResponse, error) {
+	webhook, err := s.wh.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *WebhookService) GetWebhook(ctx context.Context, req *v1.BaseRequest) (*v1.WebhookResponse, error) {
+	webhook, err := s.wh.FindByID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context, req *v1.ListWebhooksRequest) (*v1.ListWebhooksResponse, error) {
+	webhooks, err := s.wh.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, req *v1.BaseRequest) (*v1.WebhookResponse, error) {
+	webhook, err := s.wh.Delete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *WebhookService) ListWebhookDeliveries(ctx context.Context, req *v1.ListWebhookDeliveriesRequest) (*v1.ListWebhookDeliveriesResponse, error) {
+	deliveries, err := s.wh.ListDeliveries(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (s *WebhookService) RedeliverWebhook(ctx context.Context, req *v1.BaseRequest) (*v1.WebhookDelivery, error) {
+	delivery, err := s.wh.Redeliver(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
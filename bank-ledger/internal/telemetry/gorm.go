@@ -0,0 +1,77 @@
+package telemetry
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "telemetry:span"
+
+// GormPlugin records a client span for every statement run through GORM.
+func GormPlugin() gorm.Plugin {
+	return gormTracing{}
+}
+
+type gormTracing struct{}
+
+func (gormTracing) Name() string {
+	return "telemetry:tracing"
+}
+
+func (p gormTracing) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("telemetry:before_create", p.before("gorm.Create")),
+		cb.Create().After("gorm:create").Register("telemetry:after_create", p.after),
+		cb.Query().Before("gorm:query").Register("telemetry:before_query", p.before("gorm.Query")),
+		cb.Query().After("gorm:query").Register("telemetry:after_query", p.after),
+		cb.Update().Before("gorm:update").Register("telemetry:before_update", p.before("gorm.Update")),
+		cb.Update().After("gorm:update").Register("telemetry:after_update", p.after),
+		cb.Delete().Before("gorm:delete").Register("telemetry:before_delete", p.before("gorm.Delete")),
+		cb.Delete().After("gorm:delete").Register("telemetry:after_delete", p.after),
+		cb.Row().Before("gorm:row").Register("telemetry:before_row", p.before("gorm.Row")),
+		cb.Row().After("gorm:row").Register("telemetry:after_row", p.after),
+		cb.Raw().Before("gorm:raw").Register("telemetry:before_raw", p.before("gorm.Raw")),
+		cb.Raw().After("gorm:raw").Register("telemetry:after_raw", p.after),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (gormTracing) before(name string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Context == nil {
+			return
+		}
+		_, span := Tracer().Start(db.Statement.Context, name, trace.WithSpanKind(trace.SpanKindClient))
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func (gormTracing) after(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBSystemMySQL,
+		semconv.DBSQLTable(db.Statement.Table),
+		semconv.DBStatement(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,44 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MongoMonitor records a client span for every MongoDB command.
+func MongoMonitor() *event.CommandMonitor {
+	var spans sync.Map
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			_, span := Tracer().Start(ctx, "mongodb."+evt.CommandName,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					semconv.DBSystemMongoDB,
+					semconv.DBName(evt.DatabaseName),
+					semconv.DBOperation(evt.CommandName),
+				),
+			)
+			spans.Store(evt.RequestID, span)
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			if value, ok := spans.LoadAndDelete(evt.RequestID); ok {
+				value.(trace.Span).End()
+			}
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			if value, ok := spans.LoadAndDelete(evt.RequestID); ok {
+				span := value.(trace.Span)
+				span.RecordError(errors.New(evt.Failure))
+				span.SetStatus(codes.Error, evt.Failure)
+				span.End()
+			}
+		},
+	}
+}